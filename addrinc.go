@@ -0,0 +1,43 @@
+package lpsensors
+
+import "fmt"
+
+// AddrIncrement is how the sub-address is incremented on multiple-byte reads.
+type AddrIncrement int
+
+const (
+	// AddrIncDefault uses the convention of the detected chip.
+	AddrIncDefault AddrIncrement = iota
+	// AddrIncMSB requests the increment with the MSB of the I2C sub-address,
+	// or with MS(bit 6) of the SPI address, as LPS331A/LPS25H do.
+	AddrIncMSB
+	// AddrIncAuto sends the plain address, for the chips incrementing it by themselves
+	// as LPS22H does with IF_ADD_INC of CTRL_REG2.
+	AddrIncAuto
+)
+
+// String satisfies the fmt.Stringer interface.
+func (a AddrIncrement) String() string {
+	switch a {
+	case AddrIncDefault:
+		return "Default"
+	case AddrIncMSB:
+		return "MSB"
+	case AddrIncAuto:
+		return "Auto"
+	default:
+		return fmt.Sprintf("AddrIncrement(%d)", int(a))
+	}
+}
+
+// msb reports whether the increment is requested by the MSB on the chip of the register family chipType.
+func (a AddrIncrement) msb(chipType byte) bool {
+	switch a {
+	case AddrIncMSB:
+		return true
+	case AddrIncAuto:
+		return false
+	default:
+		return chipType != chipLPS22H
+	}
+}
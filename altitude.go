@@ -0,0 +1,36 @@
+package lpsensors
+
+import (
+	"math"
+
+	"periph.io/x/conn/v3/physic"
+)
+
+// StandardSeaLevel is the ISA sea-level pressure (1013.25 hPa).
+const StandardSeaLevel = 101325 * physic.Pascal
+
+// International Standard Atmosphere constants for the troposphere (below 11 km).
+const (
+	isaSeaLevelTemperature = 288.15   // K
+	isaLapseRate           = 0.0065   // K/m
+	isaExponent            = 0.190263 // R*L/(g*M)
+)
+
+// Altitude returns the altitude in meters of pressure p relative to the
+// sea-level pressure seaLevel using the international barometric formula:
+//
+//	h = T0/L * (1 - (p/p0)^(R*L/(g*M)))
+//
+// with T0 = 288.15 K and L = 0.0065 K/m. A pressure above seaLevel gives a
+// negative altitude. NaN is returned when either pressure is not positive.
+func Altitude(p, seaLevel physic.Pressure) float64 {
+	if p <= 0 || seaLevel <= 0 {
+		return math.NaN()
+	}
+	return isaSeaLevelTemperature / isaLapseRate * (1 - math.Pow(float64(p)/float64(seaLevel), isaExponent))
+}
+
+// Altitude returns the altitude of the pressure reading relative to seaLevel. See Altitude.
+func (s SensorValues) Altitude(seaLevel physic.Pressure) float64 {
+	return Altitude(s.Pressure, seaLevel)
+}
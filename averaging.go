@@ -0,0 +1,125 @@
+package lpsensors
+
+import (
+	"fmt"
+	"time"
+)
+
+// Averaging is the number of internal samples averaged per output (RES_CONF).
+// A zero field means the default used by OneShot mode:
+// pressure 512 and temperature 128 on LPS331A, pressure 512 and temperature 64 on LPS25H.
+// LPS22H has no RES_CONF and does not support it.
+type Averaging struct {
+	Pressure    int
+	Temperature int
+}
+
+// IsZero reports whether a is the default.
+func (a Averaging) IsZero() bool {
+	return a.Pressure == 0 && a.Temperature == 0
+}
+
+// avgBits maps the number of samples to the AVGP and AVGT fields of RES_CONF per chip.
+var avgBits = map[byte]struct {
+	pressure, temperature map[int]byte
+	defaults              Averaging
+}{
+	chipLPS331A: {
+		// AVGP3..0 [3:0]
+		pressure: map[int]byte{1: 0b0000, 2: 0b0001, 4: 0b0010, 8: 0b0011, 16: 0b0100, 32: 0b0101,
+			64: 0b0110, 128: 0b0111, 256: 0b1000, 384: 0b1001, 512: 0b1010},
+		// AVGT2..0 [6:4]
+		temperature: map[int]byte{1: 0b000 << 4, 2: 0b001 << 4, 4: 0b010 << 4, 8: 0b011 << 4,
+			16: 0b100 << 4, 32: 0b101 << 4, 64: 0b110 << 4, 128: 0b111 << 4},
+		defaults: Averaging{Pressure: 512, Temperature: 128},
+	},
+	chipLPS25H: {
+		// AVGP1..0 [1:0]
+		pressure: map[int]byte{8: 0b00, 32: 0b01, 128: 0b10, 512: 0b11},
+		// AVGT1..0 [3:2]
+		temperature: map[int]byte{8: 0b00 << 2, 16: 0b01 << 2, 32: 0b10 << 2, 64: 0b11 << 2},
+		defaults:    Averaging{Pressure: 512, Temperature: 64},
+	},
+}
+
+// sampleTime is a rough estimate of the time of one internal sample of LPS331A/LPS25H.
+const sampleTime = 50 * time.Microsecond
+
+// conversionTime estimates the one-shot conversion time for the averaging on the detected chip.
+// It returns zero for the chips without RES_CONF.
+func (d *Dev) conversionTime(a Averaging) time.Duration {
+	bits, ok := avgBits[d.chipType]
+	if !ok {
+		return 0
+	}
+	if a.Pressure == 0 {
+		a.Pressure = bits.defaults.Pressure
+	}
+	if a.Temperature == 0 {
+		a.Temperature = bits.defaults.Temperature
+	}
+	return time.Duration(a.Pressure+a.Temperature) * sampleTime
+}
+
+// resConfCmd returns the RES_CONF value for the averaging on the detected chip.
+func (d *Dev) resConfCmd(a Averaging) (byte, error) {
+	bits, ok := avgBits[d.chipType]
+	if !ok {
+		return 0, fmt.Errorf("%w: Averaging on %s", ErrUnsupportedOption, d.name)
+	}
+	if a.Pressure == 0 {
+		a.Pressure = bits.defaults.Pressure
+	}
+	if a.Temperature == 0 {
+		a.Temperature = bits.defaults.Temperature
+	}
+
+	p, ok := bits.pressure[a.Pressure]
+	if !ok {
+		return 0, fmt.Errorf("%w: pressure averaging %d on %s", ErrUnsupportedOption, a.Pressure, d.name)
+	}
+	t, ok := bits.temperature[a.Temperature]
+	if !ok {
+		return 0, fmt.Errorf("%w: temperature averaging %d on %s", ErrUnsupportedOption, a.Temperature, d.name)
+	}
+	return t | p, nil
+}
+
+// ConfiguredAveraging returns the averaging last written to RES_CONF, decoded from the shadow
+// of the register without a bus access. ok is false on the chips without RES_CONF (LPS22H),
+// and while RES_CONF is unknown: in Continuous mode without Opts.Averaging it is left untouched,
+// and BOOT or a reset reloads it. ControlState reads it from the device instead.
+func (d *Dev) ConfiguredAveraging() (a Averaging, ok bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.configuredAveraging()
+}
+
+func (d *Dev) configuredAveraging() (Averaging, bool) {
+	bits, ok := avgBits[d.chipType]
+	if !ok || !d.resConfShadow.valid {
+		return Averaging{}, false
+	}
+	return Averaging{
+		Pressure:    decodeAveraging(bits.pressure, d.resConfShadow.val),
+		Temperature: decodeAveraging(bits.temperature, d.resConfShadow.val),
+	}, true
+}
+
+// AveragingSummary describes ConfiguredAveraging for humans, e.g.
+// "pressure avg 512 samples, temperature avg 128 samples".
+// It is "n/a" on the chips without RES_CONF and "unknown" while RES_CONF is unknown.
+func (d *Dev) AveragingSummary() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := avgBits[d.chipType]; !ok {
+		return "n/a"
+	}
+	a, ok := d.configuredAveraging()
+	if !ok {
+		return "unknown"
+	}
+	return fmt.Sprintf("pressure avg %d samples, temperature avg %d samples", a.Pressure, a.Temperature)
+}
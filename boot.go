@@ -0,0 +1,88 @@
+package lpsensors
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Boot time of the chips, that is the time to reload the trimming parameters after BOOT[7].
+// LPS25H takes 2.2 msec. LPS331A and LPS22H do not specify it; 10 msec is waited as Boot does.
+const (
+	bootTimeLPS331A = 10 * time.Millisecond
+	bootTimeLPS25H  = 2200 * time.Microsecond
+	bootTimeLPS22H  = 10 * time.Millisecond
+)
+
+// bootTime returns the boot time of the detected chip.
+func (d *Dev) bootTime() time.Duration {
+	switch d.chipType {
+	case chipLPS25H:
+		return bootTimeLPS25H
+	case chipLPS22H:
+		return bootTimeLPS22H
+	default:
+		return bootTimeLPS331A
+	}
+}
+
+// BootAndWait sends BOOT[7] command to the device and waits for the boot to complete.
+// LPS25H completes when BOOT[7] is cleared, and LPS22H when BOOT_STATUS of INT_SOURCE is cleared as well.
+// LPS331A does not report it, so the boot time is waited after BOOT[7] is cleared.
+func (d *Dev) BootAndWait(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// BOOT reloads the registers.
+	d.invalidateShadows()
+
+	// set and check BOOT[7]
+	if err := d.setAndCheckCtrlReg2(ctx, 0b10000000, pollOpts{}); err != nil {
+		return d.wrap(fmt.Errorf("BootAndWait: %w", err))
+	}
+
+	switch d.chipType {
+	case chipLPS25H:
+		return nil
+	case chipLPS22H:
+		if err := d.waitBootStatus(ctx); err != nil {
+			return d.wrap(fmt.Errorf("BootAndWait: %w", err))
+		}
+		return nil
+	default:
+		if err := waitCancel(ctx, d.clock.NewTimer(d.bootTime())); err != nil {
+			return d.wrap(fmt.Errorf("BootAndWait: %w", err))
+		}
+		return nil
+	}
+}
+
+// waitBootStatus polls BOOT_STATUS[7] of INT_SOURCE of LPS22H until it is cleared.
+// INT_SOURCE is 0x25 on LPS22HB and 0x24 on LPS22HH.
+// It gives up with ErrMeasurementTimeout after the boot time.
+func (d *Dev) waitBootStatus(ctx context.Context) error {
+	const interval = 500 * time.Microsecond
+	maxPolls := int(d.bootTime()/interval) + 1
+
+	reg := d.intSourceReg()
+
+	b := [1]byte{}
+	timer := d.clock.NewTimer(interval)
+	for polls := 1; ; polls++ {
+		if err := d.readReg(ctx, reg, b[:]); err != nil {
+			return fmt.Errorf("waitBootStatus: failed read from INT_SOURCE(0x%x): %w", reg, err)
+		}
+		if b[0]&0b10000000 == 0 {
+			return nil
+		}
+		if polls >= maxPolls {
+			return fmt.Errorf("waitBootStatus: BOOT_STATUS of INT_SOURCE(0x%x) not cleared after %d polls: %w",
+				reg, polls, ErrMeasurementTimeout)
+		}
+
+		timer.Reset(interval)
+		if err := waitCancel(ctx, timer); err != nil {
+			return fmt.Errorf("waitBootStatus: %w", err)
+		}
+	}
+}
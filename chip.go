@@ -0,0 +1,59 @@
+package lpsensors
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+)
+
+// ChipProfile describes a chip unknown to the package, registered by RegisterChip.
+// The chip is accessed like LPS331A/LPS25H: multiple bytes are read with MSB of the
+// sub-address set, and STATUS_REG has T_DA[0] and P_DA[1]. It has no RES_CONF,
+// so Averaging is not supported, and the optional features are not available.
+type ChipProfile struct {
+	// Name is the name of the chip, e.g. "LPS331A".
+	Name string
+	// CtrlReg1 and CtrlReg2 are the addresses of CTRL_REG1 and CTRL_REG2.
+	CtrlReg1, CtrlReg2 byte
+	// ODRBits maps the ODRs the chip supports to ODR[2:0] of CTRL_REG1, bits [6:4].
+	ODRBits map[ODR]byte
+	// DefaultODR is the ODR used for ODRDefault. It must be in ODRBits.
+	DefaultODR ODR
+	// PowerDown is true when PD[7] of CTRL_REG1 has to be set to turn on the chip.
+	PowerDown bool
+	// BDU is the Block Data Update bit of CTRL_REG1.
+	BDU byte
+	// TemperatureScale converts TEMP_OUT to the temperature.
+	TemperatureScale TemperatureScale
+	// StatusReg, PressOut and TempOut are the addresses of STATUS_REG, PRESS_OUT_XL and TEMP_OUT_L.
+	// Zero means 0x27, 0x28 and 0x2b respectively.
+	StatusReg, PressOut, TempOut byte
+}
+
+var (
+	chipsMu sync.RWMutex
+	chips   = map[byte]ChipProfile{}
+)
+
+// RegisterChip makes the devices answering id to WHO_AM_I usable with the profile.
+// The built-in chips are detected first, so it panics on their ids.
+// Registering an id again replaces its profile.
+func RegisterChip(id byte, profile ChipProfile) {
+	switch id {
+	case chipLPS331A, chipLPS25H, chipLPS22H, chipLPS22HH:
+		panic(fmt.Sprintf("lps: RegisterChip: 0x%02x is a built-in chip", id))
+	}
+	profile.ODRBits = maps.Clone(profile.ODRBits)
+
+	chipsMu.Lock()
+	defer chipsMu.Unlock()
+	chips[id] = profile
+}
+
+// registeredChip returns the profile registered for id.
+func registeredChip(id byte) (ChipProfile, bool) {
+	chipsMu.RLock()
+	defer chipsMu.RUnlock()
+	p, ok := chips[id]
+	return p, ok
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/walkure/go-lpsensors"
 	"periph.io/x/conn/v3/i2c/i2creg"
@@ -12,8 +13,6 @@ import (
 
 func main() {
 
-	slog.SetLogLoggerLevel(slog.LevelDebug)
-
 	if _, err := host.Init(); err != nil {
 		panic(fmt.Sprint("i2c initialize error: ", err))
 	}
@@ -28,6 +27,10 @@ func main() {
 	d, err := lpsensors.NewI2C(bus, 0x5c, &lpsensors.Opts{
 		//Mode: lpsensors.OneShot,
 		Mode: lpsensors.Continuous,
+		//SkipInit: true, // for the manual Boot, SWReset and Init below
+		Logger: slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+		})),
 	})
 	if err != nil {
 		fmt.Println("lps err:", err)
@@ -49,6 +52,11 @@ func main() {
 			return
 		}
 	*/
+	if err := d.Ready(context.TODO()); err != nil {
+		fmt.Println("ready err:", err)
+		return
+	}
+
 	data := lpsensors.SensorValues{}
 	if err := d.Sense(context.TODO(), &data); err != nil {
 		fmt.Println("sense err:", err)
@@ -8,95 +8,240 @@ import (
 	"time"
 )
 
-func (d *Dev) readReg(reg uint8, b []byte) error {
+func (d *Dev) readReg(ctx context.Context, reg uint8, b []byte) error {
 	// SPI bus interface
+	if d.isSPI && d.spi3Wire {
+		// 3-wire SPI: the address is written, then SDI/SDO is released to read the data.
+		addr := reg | 0x80
+		if len(b) > 1 && d.msbIncrement {
+			addr |= 0x40
+		}
+		write := d.spiBuf[:1]
+		write[0] = addr
+		if err := d.tx(ctx, write, b); err != nil {
+			return fmt.Errorf("sr: %w", err)
+		}
+		d.debugRead("spi", reg, b)
+		return nil
+	}
 	if d.isSPI {
 		// MSB is 0 for write and 1 for read.
-		read := make([]byte, len(b)+1)
-		write := make([]byte, len(read))
+		var read, write []byte
+		if len(b) <= maxBurst {
+			read = d.spiBuf[:len(b)+1]
+			write = d.spiBuf[maxBurst+1 : maxBurst+1+len(read)]
+			clear(write)
+		} else {
+			read = make([]byte, len(b)+1)
+			write = make([]byte, len(read))
+		}
 		// Rest of the write buffer is ignored.
-		write[0] = reg
-		if err := d.d.Tx(write, read); err != nil {
+		write[0] = reg | 0x80
+		// LPS331A/LPS25H increment the address on multiple reads only with MS(bit 6).
+		// LPS22H increments by IF_ADD_INC of CTRL_REG2 instead.
+		if len(b) > 1 && d.msbIncrement {
+			write[0] |= 0x40
+		}
+		if err := d.tx(ctx, write, read); err != nil {
 			return fmt.Errorf("sr: %w", err)
 		}
-		slog.Debug("readReg", "spi", dumpRead(reg, b))
 		copy(b, read[1:])
+		d.debugRead("spi", reg, b)
 		return nil
 	}
-	if err := d.d.Tx([]byte{reg}, b); err != nil {
+	addr := reg
+	if len(b) > 1 && d.msbIncrement {
+		// LPS331A/LPS25H increment the sub-address only with MSB set.
+		// LPS22H increments it by IF_ADD_INC of CTRL_REG2 and has no such bit.
+		addr |= 0x80
+	}
+	if err := d.tx(ctx, []byte{addr}, b); err != nil {
 		return fmt.Errorf("ir: %w", err)
 	}
-	slog.Debug("readReg", "i2c", dumpRead(reg, b))
+	d.debugRead("i2c", reg, b)
 	return nil
 }
 
+// maxBurst is the longest read the driver performs other than ReadRegister:
+// STATUS_REG, PRESS_OUT and TEMP_OUT in sense.
+const maxBurst = 6
+
+// debugRead logs the read data, building the dump only when debug logging is enabled.
+func (d *Dev) debugRead(bus string, reg uint8, b []byte) {
+	if d.logger.Enabled(context.Background(), slog.LevelDebug) {
+		d.logger.Debug("readReg", bus, dumpRead(reg, b))
+	}
+}
+
 func dumpRead(reg uint8, b []byte) string {
 	resp := make([]string, 0, len(b))
 	for i := 0; i < len(b); i++ {
 		resp = append(resp, fmt.Sprintf("0x%02x", b[i]))
 	}
 
-	if reg&0x80 != 0 {
-		return fmt.Sprintf("multuple read from 0x%02x: %s", reg&^uint8(0x80), strings.Join(resp, ","))
+	if len(b) > 1 {
+		return fmt.Sprintf("multuple read from 0x%02x: %s", reg, strings.Join(resp, ","))
 	}
 
 	return fmt.Sprintf("single read from 0x%02x: %s", reg, strings.Join(resp, ","))
 }
 
-func (d *Dev) writeCommands(b []byte) error {
+// writeCommands writes b as pairs of a register address and its value.
+// Each pair is sent as its own transaction; b is left untouched.
+func (d *Dev) writeCommands(ctx context.Context, b []byte) error {
 
 	comType := "i"
-	// SPI bus interface
 	if d.isSPI {
-		// "SPI write"; set RW(MSB) to 0.
+		comType = "s"
+	}
+	if d.logger.Enabled(context.Background(), slog.LevelDebug) {
+		attrs := make([]slog.Attr, 0, len(b)/2)
 		for i := 0; i < len(b); i += 2 {
-			b[i] &^= 0x80
+			attrs = append(attrs, slog.String(fmt.Sprintf("0x%02x", b[i]), fmt.Sprintf("<-0x%08b(0x%02x)", b[i+1], b[i+1])))
 		}
-		comType = "s"
+		d.logger.Debug("writeCommands", comType, attrs)
 	}
-	attrs := make([]slog.Attr, 0, len(b)/2)
-	for i := 0; i < len(b); i += 2 {
-		attrs = append(attrs, slog.String(fmt.Sprintf("0x%02x", b[i]), fmt.Sprintf("<-0x%08b(0x%02x)", b[i+1], b[i+1])))
+
+	for i := 0; i+1 < len(b); i += 2 {
+		w := [2]byte{b[i], b[i+1]}
+		// SPI interface
+		if d.isSPI {
+			// "SPI write"; set RW(MSB) to 0.
+			w[0] &^= 0x80
+		}
+		err := d.tx(ctx, w[:], nil)
+		if s := d.shadowOf(b[i]); s != nil {
+			// The register is unknown after a failed write.
+			*s = regShadow{val: b[i+1], valid: err == nil}
+		}
+		if err != nil {
+			return fmt.Errorf("%sw: %w", comType, err)
+		}
 	}
-	slog.Debug("writeCommands", comType, attrs)
+	return nil
+}
 
-	if err := d.d.Tx(b, nil); err != nil {
-		return fmt.Errorf("%sw: %w", comType, err)
+// regShadow is the last value written to a register by the driver.
+type regShadow struct {
+	val   byte
+	valid bool
+}
+
+// is reports whether the register is known to hold v.
+func (s regShadow) is(v byte) bool {
+	return s.valid && s.val == v
+}
+
+// shadowOf returns the shadow of reg, or nil when reg is not shadowed.
+// Only CTRL_REG1 and RES_CONF are shadowed; the bits of CTRL_REG2 clear themselves.
+func (d *Dev) shadowOf(reg byte) *regShadow {
+	switch {
+	case reg == d.regs.ctrl_reg1:
+		return &d.ctrl1Shadow
+	case d.regs.res_conf != 0 && reg == d.regs.res_conf:
+		return &d.resConfShadow
 	}
 	return nil
 }
 
+// invalidateShadows forgets the shadowed registers, e.g. after the device reloads them.
+func (d *Dev) invalidateShadows() {
+	d.ctrl1Shadow = regShadow{}
+	d.resConfShadow = regShadow{}
+}
+
+// tx runs a bus transaction, retrying it Opts.Retries times on error.
+// The delay before a retry starts at Opts.RetryDelay and doubles every time.
+// It does not start a transaction once ctx is done.
+func (d *Dev) tx(ctx context.Context, w, r []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	err := d.d.Tx(w, r)
+	if err == nil || d.opts.Retries <= 0 {
+		return err
+	}
+
+	delay := d.opts.RetryDelay
+	for i := 1; i <= d.opts.Retries; i++ {
+		d.logger.Debug("tx", "retry", i, "delay", delay, "err", err)
+		if werr := waitCancel(ctx, d.clock.NewTimer(delay)); werr != nil {
+			return fmt.Errorf("retry canceled (%v): %w", werr, err)
+		}
+		if err = d.d.Tx(w, r); err == nil {
+			return nil
+		}
+		delay *= 2
+	}
+	return fmt.Errorf("failed after %d retries: %w", d.opts.Retries, err)
+}
+
 func (d *Dev) wrap(err error) error {
-	return fmt.Errorf("%s: %w", strings.ToLower(d.name), err)
+	return wrapName(d.name, err)
+}
+
+// wrapName is wrap with the chip name read under mu, for the errors returned after releasing it.
+func wrapName(name string, err error) error {
+	return fmt.Errorf("%s: %w", strings.ToLower(name), err)
 }
 
-func waitCancel(ctx context.Context, t *time.Timer) error {
+func waitCancel(ctx context.Context, t timer) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
-	case <-t.C:
+	case <-t.C():
 		return nil
 	}
 }
 
-func (d *Dev) setAndCheckCtrlReg2(ctx context.Context, value byte) error {
-	if err := d.writeCommands(
+// defaultPollInterval is the interval between polls of a flag unless specified.
+// BOOT takes 2.2 msec. SWRESET takes  4 μsec (LPS25H)
+const defaultPollInterval = 5 * time.Millisecond
+
+// pollOpts specifies how a flag is polled.
+type pollOpts struct {
+	// interval is the time between polls; zero means defaultPollInterval.
+	interval time.Duration
+	// maxPolls limits the number of reads when positive.
+	maxPolls int
+	// timeout limits the whole polling when positive.
+	timeout time.Duration
+}
+
+// exhausted reports whether polling has to give up after polls reads in elapsed.
+func (p pollOpts) exhausted(polls int, elapsed time.Duration) bool {
+	if p.maxPolls > 0 && polls >= p.maxPolls {
+		return true
+	}
+	return p.timeout > 0 && elapsed >= p.timeout
+}
+
+// next returns the time to wait before the next poll.
+func (p pollOpts) next() time.Duration {
+	if p.interval <= 0 {
+		return defaultPollInterval
+	}
+	return p.interval
+}
+
+// setAndCheckCtrlReg2 sets value to CTRL_REG2 and polls until the bits are cleared.
+// It gives up with ErrMeasurementTimeout once p is exhausted.
+func (d *Dev) setAndCheckCtrlReg2(ctx context.Context, value byte, p pollOpts) error {
+	start := d.clock.Now()
+	if err := d.writeCommands(ctx,
 		[]byte{
 			d.regs.ctrl_reg2,
-			value,
+			d.ctrl2Base | value,
 		}); err != nil {
 		return fmt.Errorf("setAndCheckCtrlReg2: failed to write value 0b%08b(0x%x) command CTRL_REG2(0x%x): %w",
 			value, value, d.regs.ctrl_reg2, err)
 	}
 
 	b := [1]byte{}
+	timer := d.clock.NewTimer(p.next())
 
-	// BOOT takes 2.2 msec. SWRESET takes  4 μsec (LPS25H)
-	const timeout = 5 * time.Millisecond
-	timer := time.NewTimer(timeout)
-
-	for {
-		if err := d.readReg(d.regs.ctrl_reg2, b[:]); err != nil {
+	for polls := 1; ; polls++ {
+		if err := d.readReg(ctx, d.regs.ctrl_reg2, b[:]); err != nil {
 			return fmt.Errorf("setAndCheckCtrlReg2: failed read from CTRL_REG2(0x%x): %w",
 				d.regs.ctrl_reg2, err)
 		}
@@ -104,13 +249,69 @@ func (d *Dev) setAndCheckCtrlReg2(ctx context.Context, value byte) error {
 		if b[0]&value == 0 {
 			return nil
 		}
+		if p.exhausted(polls, d.clock.Now().Sub(start)) {
+			return fmt.Errorf("setAndCheckCtrlReg2: 0b%08b(0x%x) of CTRL_REG2(0x%x) not cleared after %d polls: %w",
+				value, value, d.regs.ctrl_reg2, polls, ErrMeasurementTimeout)
+		}
 
-		timer.Reset(timeout)
+		timer.Reset(p.next())
 		select {
 		case <-ctx.Done():
 			return fmt.Errorf("setAndCheckCtrlReg2: %w", ctx.Err())
-		case <-timer.C:
+		case <-timer.C():
 			// spin..
 		}
 	}
 }
+
+// waitStatus polls STATUS_REG until all bits of mask are set.
+// It gives up with ErrMeasurementTimeout once p is exhausted.
+func (d *Dev) waitStatus(ctx context.Context, mask byte, p pollOpts) error {
+	start := d.clock.Now()
+	b := [1]byte{}
+
+	timer := d.clock.NewTimer(p.next())
+
+	for polls := 1; ; polls++ {
+		if err := d.readReg(ctx, d.regs.status_reg, b[:]); err != nil {
+			return fmt.Errorf("waitStatus: failed read from STATUS_REG(0x%x): %w", d.regs.status_reg, err)
+		}
+		if b[0]&mask == mask {
+			return nil
+		}
+		if p.exhausted(polls, d.clock.Now().Sub(start)) {
+			return fmt.Errorf("waitStatus: 0b%08b(0x%x) of STATUS_REG(0x%x) not set after %d polls: %w",
+				mask, mask, d.regs.status_reg, polls, ErrMeasurementTimeout)
+		}
+
+		timer.Reset(p.next())
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waitStatus: %w", ctx.Err())
+		case <-timer.C():
+			// spin..
+		}
+	}
+}
+
+// statusDA returns T_DA and P_DA bits of STATUS_REG.
+func (d *Dev) statusDA() (tDA, pDA byte) {
+	switch d.chipType {
+	case chipLPS22H:
+		return 0b10, 0b01
+	default:
+		// LPS331A, LPS25H
+		return 0b01, 0b10
+	}
+}
+
+// statusOR returns T_OR and P_OR bits of STATUS_REG.
+func (d *Dev) statusOR() (tOR, pOR byte) {
+	switch d.chipType {
+	case chipLPS22H:
+		return 0b100000, 0b010000
+	default:
+		// LPS331A, LPS25H
+		return 0b010000, 0b100000
+	}
+}
@@ -0,0 +1,168 @@
+package lpsensors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+)
+
+// SenseContinuous reads a sample every interval and sends it to the returned channel.
+// An interval shorter than the conversion period just returns the latest sample again.
+// A non-positive interval means the conversion period of the configured ODR, also in OneShot mode.
+// Read errors are sent to the error channel; both channels must be drained.
+// Both channels are closed once ctx is done.
+func (d *Dev) SenseContinuous(ctx context.Context, interval time.Duration) (<-chan SensorValues, <-chan error) {
+	if interval <= 0 {
+		// Not SamplePeriod, which is zero in OneShot mode.
+		d.mu.Lock()
+		interval = d.period
+		d.mu.Unlock()
+	}
+	return d.senseStream(ctx, nil, gpio.High, interval)
+}
+
+// SenseContinuousOnInterrupt reads a sample on every active edge of the INT pin, Opts.IntPin,
+// and sends it to the returned channel. It is the falling edge with IntPinOpts.ActiveLow.
+// The data-ready signal is routed to the INT pin first, like WaitForData, and a sample
+// already waiting at the start is read without an edge.
+// Without IntPin, it reads a sample every conversion period of the device instead.
+// Read errors are sent to the error channel; both channels must be drained.
+// Both channels are closed once ctx is done, or after the error when the routing fails.
+func (d *Dev) SenseContinuousOnInterrupt(ctx context.Context) (<-chan SensorValues, <-chan error) {
+	d.mu.Lock()
+	pin, period := d.intPin, d.period
+	active := gpio.High
+	if d.opts.IntPinOpts.ActiveLow {
+		active = gpio.Low
+	}
+	var err error
+	if pin != nil {
+		if err = d.enableDataReady(ctx); err != nil {
+			err = d.wrap(fmt.Errorf("SenseContinuousOnInterrupt: %w", err))
+		}
+	}
+	d.mu.Unlock()
+	if err != nil {
+		return errStream(ctx, err)
+	}
+	return d.senseStream(ctx, pin, active, period)
+}
+
+// errStream returns the channels of a stream which fails to start: err is sent, then both are closed.
+func errStream(ctx context.Context, err error) (<-chan SensorValues, <-chan error) {
+	values := make(chan SensorValues)
+	errs := make(chan error)
+	go func() {
+		defer close(errs)
+		defer close(values)
+		select {
+		case errs <- err:
+		case <-ctx.Done():
+		}
+	}()
+	return values, errs
+}
+
+// senseStream reads samples on the edges of pin to the active level, or every interval without pin.
+func (d *Dev) senseStream(ctx context.Context, pin gpio.PinIn, active gpio.Level, interval time.Duration) (<-chan SensorValues, <-chan error) {
+	values := make(chan SensorValues)
+	errs := make(chan error)
+
+	go func() {
+		defer close(errs)
+		defer close(values)
+
+		var tk ticker
+		if pin == nil {
+			tk = d.clock.NewTicker(interval)
+			defer tk.Stop()
+		}
+
+		// DRDY is a level: a sample waiting at the start raises no edge.
+		pending := pin != nil && pin.Read() == active
+		for {
+			if pending {
+				pending = false
+			} else if err := waitSample(ctx, tk, pin, interval); err != nil {
+				return
+			}
+
+			var e SensorValues
+			if err := d.Sense(ctx, &e); err != nil {
+				select {
+				case errs <- err:
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case values <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return values, errs
+}
+
+// SamplePeriod returns the interval between conversions at the configured ODR,
+// e.g. 80 msec for 12.5Hz. It is zero in OneShot mode.
+func (d *Dev) SamplePeriod() time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.oneshotMode {
+		return 0
+	}
+	return d.period
+}
+
+// Ready blocks until the first conversion after Init is available in Continuous mode.
+// The device needs up to a conversion period after it is powered on by Init, and Sense
+// reads stale or zero values until then. It returns immediately in OneShot mode and once
+// the device has been seen ready. It gives up with ErrMeasurementTimeout after two
+// conversion periods plus the averaging time.
+func (d *Dev) Ready(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.oneshotMode || d.ready {
+		return nil
+	}
+
+	tDA, pDA := d.statusDA()
+	p := pollOpts{interval: time.Millisecond, timeout: 2*d.period + d.conversionTime(d.opts.Averaging)}
+	if err := d.waitStatus(ctx, tDA|pDA, p); err != nil {
+		return d.wrap(fmt.Errorf("Ready: %w", err))
+	}
+	d.ready = true
+	return nil
+}
+
+// waitSample blocks until the next edge of pin, or the next tick with tk.
+// The context is checked every period while waiting for an edge.
+func waitSample(ctx context.Context, tk ticker, pin gpio.PinIn, period time.Duration) error {
+	if tk != nil {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tk.C():
+			return nil
+		}
+	}
+
+	for {
+		// WaitForEdge is not cancellable; check ctx every period.
+		if pin.WaitForEdge(period) {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
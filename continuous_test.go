@@ -0,0 +1,343 @@
+package lpsensors_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/walkure/go-lpsensors"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+	"periph.io/x/conn/v3/i2c/i2ctest"
+	"periph.io/x/conn/v3/physic"
+)
+
+func Test_LPS331A_SenseContinuousOnInterrupt(t *testing.T) {
+	read := []i2ctest.IO{
+		{
+			// Read STATUS_REG, PRESS_OUT_XL, PRESS_OUT_L, PRESS_OUT_H, TEMP_OUT_L, TEMP_OUT_H
+			// (0x3f5000=4149248) / 4096 = 1013 hPa
+			// (0x6bd0 = 27600) / 480 + 42.5 = 100 degC
+			Addr: LPS331A_addr,
+			W:    []byte{0x27 | 0x80},
+			R:    []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b},
+		},
+	}
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+		i2ctest.IO{
+			// CTRL_REG3 INT1_S = 0b100: data ready
+			Addr: LPS331A_addr,
+			W:    []byte{0x22, 0b100},
+		},
+	)
+	ops = append(ops, read...)
+	ops = append(ops, read...)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	// The pin is low, so the stream waits for an edge.
+	pin := &gpiotest.Pin{N: "INT", EdgesChan: make(chan gpio.Level, 2)}
+	d, err := lpsensors.NewI2C(&bus, 0x5c, &lpsensors.Opts{
+		Mode:   lpsensors.Continuous,
+		IntPin: pin,
+	})
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	values, errs := d.SenseContinuousOnInterrupt(ctx)
+
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+
+	for i := 0; i < 2; i++ {
+		pin.EdgesChan <- gpio.High
+		select {
+		case v := <-values:
+			assert.Equal(t, tp, v.Pressure)
+		case err := <-errs:
+			t.Fatalf("sense err: %v", err)
+		}
+	}
+
+	cancel()
+	for range values {
+	}
+	for range errs {
+	}
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_SenseContinuousOnInterrupt_Pending(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+			// CTRL_REG3 INT1_S = 0b100: data ready
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x22, 0b100}},
+			// Read STATUS_REG, PRESS_OUT and TEMP_OUT: 1013 hPa, 100 degC
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b}},
+		),
+	}
+
+	// A sample is already waiting: the pin is high and no edge comes.
+	pin := &gpiotest.Pin{N: "INT", L: gpio.High, EdgesChan: make(chan gpio.Level)}
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, &lpsensors.Opts{
+		Mode:   lpsensors.Continuous,
+		IntPin: pin,
+	})
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	values, errs := d.SenseContinuousOnInterrupt(ctx)
+
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+	select {
+	case v := <-values:
+		assert.Equal(t, tp, v.Pressure)
+	case err := <-errs:
+		t.Fatalf("sense err: %v", err)
+	}
+
+	cancel()
+	for range values {
+	}
+	for range errs {
+	}
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_SenseContinuous(t *testing.T) {
+	read := []i2ctest.IO{
+		{
+			// Read STATUS_REG, PRESS_OUT_XL, PRESS_OUT_L, PRESS_OUT_H, TEMP_OUT_L, TEMP_OUT_H
+			// (0x3f5000=4149248) / 4096 = 1013 hPa
+			// (0x6bd0 = 27600) / 480 + 42.5 = 100 degC
+			Addr: LPS331A_addr,
+			W:    []byte{0x27 | 0x80},
+			R:    []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b},
+		},
+	}
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+	)
+	for i := 0; i < 3; i++ {
+		ops = append(ops, read...)
+	}
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, 0x5c, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	clock := lpsensors.UseFakeClock(d, time.Unix(0, 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	// Shorter than the conversion period of 80 msec.
+	values, errs := d.SenseContinuous(ctx, time.Millisecond)
+
+	var tc physic.Temperature
+	tc.Set("100C")
+
+	for i := 0; i < 3; i++ {
+		clock.Tick(time.Millisecond)
+		select {
+		case v := <-values:
+			assert.Equal(t, tc, v.Temperature)
+		case err := <-errs:
+			t.Fatalf("sense err: %v", err)
+		}
+	}
+
+	cancel()
+	for range values {
+	}
+	for range errs {
+	}
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_SenseContinuous_OneShot(t *testing.T) {
+	ops := append(init_LPS331AOps(),
+		// CTRL_REG1 power-off device, RES_CONF, CTRL_REG1 power-on as one-shot mode
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1, 0x00}},
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_RES_CONF, 0x7a}},
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1, 0b10000100}},
+		// CTRL_REG2 ONE_SHOT up, then down
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2, 0x01}},
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2}, R: []byte{0x00}},
+		// Read STATUS_REG, PRESS_OUT and TEMP_OUT: 1013 hPa, 100 degC
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b}},
+	)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, &lpsensors.Opts{Mode: lpsensors.OneShot})
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	clock := lpsensors.UseFakeClock(d, time.Unix(0, 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	// Every conversion period of the ODR, although SamplePeriod is zero.
+	values, errs := d.SenseContinuous(ctx, 0)
+
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+
+	clock.Tick(80 * time.Millisecond)
+	select {
+	case v := <-values:
+		assert.Equal(t, tp, v.Pressure)
+	case err := <-errs:
+		t.Fatalf("sense err: %v", err)
+	}
+
+	cancel()
+	for range values {
+	}
+	for range errs {
+	}
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_Env(t *testing.T) {
+	read := i2ctest.IO{
+		// Read STATUS_REG, PRESS_OUT and TEMP_OUT: 1013 hPa, 100 degC
+		Addr: LPS331A_addr,
+		W:    []byte{0x27 | 0x80},
+		R:    []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b},
+	}
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+			read, read,
+			// CTRL_REG1 power-off device by Halt
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1, 0x00}},
+		),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	clock := lpsensors.UseFakeClock(d, time.Unix(0, 0))
+
+	env := d.Env()
+	assert.Equal(t, "LPS331A{I2C:0x5c}", env.String())
+
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+
+	var e physic.Env
+	assert.NoError(t, env.Sense(&e))
+	assert.Equal(t, tp, e.Pressure)
+
+	var p physic.Env
+	env.Precision(&p)
+	assert.Equal(t, physic.Kelvin/480, p.Temperature)
+	assert.Equal(t, 100*physic.Pascal/4096, p.Pressure)
+
+	c, err := env.SenseContinuous(time.Second)
+	assert.NoError(t, err)
+	_, err = env.SenseContinuous(time.Second)
+	assert.Error(t, err)
+	clock.Tick(time.Second)
+	assert.Equal(t, tp, (<-c).Pressure)
+
+	assert.NoError(t, env.Halt())
+	_, ok := <-c
+	assert.False(t, ok)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_Ready(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+			// STATUS_REG: the first conversion is not done, then P_DA and T_DA
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27}, R: []byte{0x00}},
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27}, R: []byte{0x03}},
+		),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	assert.NoError(t, d.Ready(context.TODO()))
+	// Already ready: no bus access.
+	assert.NoError(t, d.Ready(context.TODO()))
+	assert.NoError(t, bus.Close())
+}
+
+func Test_SamplePeriod(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(), i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		}),
+	}
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	// 12.5Hz
+	assert.Equal(t, 80*time.Millisecond, d.SamplePeriod())
+
+	bus = i2ctest.Playback{
+		Ops: append(init_LPS22HOps(), i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement at 75Hz: ODR=0b101 BDU=1
+			Addr: LPS22H_addr,
+			W:    []byte{LPS22H_CTRL_REG1, 0b01010010},
+		}),
+	}
+	d, err = lpsensors.NewI2CWithOptions(&bus, LPS22H_addr, lpsensors.WithODR(lpsensors.ODR75Hz))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.Equal(t, time.Second/75, d.SamplePeriod())
+
+	bus = i2ctest.Playback{
+		Ops: init_LPS331AOps(),
+	}
+	d, err = lpsensors.NewI2C(&bus, LPS331A_addr, &lpsensors.Opts{Mode: lpsensors.OneShot})
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.Zero(t, d.SamplePeriod())
+}
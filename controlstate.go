@@ -0,0 +1,104 @@
+package lpsensors
+
+import (
+	"context"
+	"fmt"
+)
+
+// ControlState is the configuration decoded from the control registers of the device.
+type ControlState struct {
+	// CtrlReg1, CtrlReg2 and ResConf are the raw values. ResConf is zero on the chips without it.
+	CtrlReg1, CtrlReg2, ResConf byte
+	// PowerDown is true when the device is powered down: PD[7] is cleared on LPS331A/LPS25H,
+	// or ODR[2:0] is zero (one-shot) on LPS22H, which has no PD bit.
+	PowerDown bool
+	// ODR is the output data rate, or ODRDefault when ODR[2:0] is zero (one-shot) or unknown.
+	ODR ODR
+	// BDU is true when the Block Data Update is enabled.
+	BDU bool
+	// OneShot is true while ONE_SHOT[0] of CTRL_REG2 is set, i.e. a one-shot measurement is running.
+	OneShot bool
+	// Averaging is decoded from RES_CONF on LPS331A/LPS25H and zero on the others.
+	Averaging Averaging
+	// LowPassFilter is decoded from EN_LPFP[3] and LPFP_CFG[2] of CTRL_REG1 on LPS22H.
+	LowPassFilter LowPassFilter
+}
+
+// ControlState reads and decodes CTRL_REG1, CTRL_REG2 and RES_CONF, e.g. to check
+// the device still has the configuration given by Init after a brownout.
+func (d *Dev) ControlState() (ControlState, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var s ControlState
+	ctx := context.Background()
+	b := [1]byte{}
+	if err := d.readReg(ctx, d.regs.ctrl_reg1, b[:]); err != nil {
+		return s, d.wrap(fmt.Errorf("ControlState: failed to read CTRL_REG1(0x%x): %w", d.regs.ctrl_reg1, err))
+	}
+	s.CtrlReg1 = b[0]
+	if err := d.readReg(ctx, d.regs.ctrl_reg2, b[:]); err != nil {
+		return s, d.wrap(fmt.Errorf("ControlState: failed to read CTRL_REG2(0x%x): %w", d.regs.ctrl_reg2, err))
+	}
+	s.CtrlReg2 = b[0]
+	if d.regs.res_conf != 0 {
+		if err := d.readReg(ctx, d.regs.res_conf, b[:]); err != nil {
+			return s, d.wrap(fmt.Errorf("ControlState: failed to read RES_CONF(0x%x): %w", d.regs.res_conf, err))
+		}
+		s.ResConf = b[0]
+	}
+
+	// ODR[6:4]
+	odr := s.CtrlReg1 >> 4 & 0b111
+	chipODRs := odrBits[d.chipType]
+	if d.profile != nil {
+		chipODRs = d.profile.ODRBits
+	}
+	for o, bits := range chipODRs {
+		if bits == odr {
+			s.ODR = o
+		}
+	}
+	s.OneShot = s.CtrlReg2&0b1 != 0
+
+	switch {
+	case d.chipType == chipLPS22H:
+		s.PowerDown = odr == 0
+		s.BDU = s.CtrlReg1&(1<<1) != 0
+		switch s.CtrlReg1 & LPFODR20.bits() {
+		case LPFODR9.bits():
+			s.LowPassFilter = LPFODR9
+		case LPFODR20.bits():
+			s.LowPassFilter = LPFODR20
+		}
+	case d.profile != nil:
+		s.PowerDown = d.profile.PowerDown && s.CtrlReg1&(1<<7) == 0
+		s.BDU = d.profile.BDU != 0 && s.CtrlReg1&d.profile.BDU != 0
+	default:
+		// LPS331A, LPS25H
+		s.PowerDown = s.CtrlReg1&(1<<7) == 0
+		s.BDU = s.CtrlReg1&(1<<2) != 0
+	}
+
+	if bits, ok := avgBits[d.chipType]; ok {
+		s.Averaging = Averaging{
+			Pressure:    decodeAveraging(bits.pressure, s.ResConf),
+			Temperature: decodeAveraging(bits.temperature, s.ResConf),
+		}
+	}
+	return s, nil
+}
+
+// decodeAveraging returns the number of samples of the field of RES_CONF in bits, or zero if reserved.
+func decodeAveraging(bits map[int]byte, resConf byte) int {
+	var mask byte
+	for _, b := range bits {
+		mask |= b
+	}
+	for n, b := range bits {
+		if resConf&mask == b {
+			return n
+		}
+	}
+	return 0
+}
@@ -0,0 +1,73 @@
+package lpsensors_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/walkure/go-lpsensors"
+	"periph.io/x/conn/v3/physic"
+)
+
+func FuzzDecodePressure(f *testing.F) {
+	f.Add(byte(0x00), byte(0x50), byte(0x3f)) // 1013 hPa
+	f.Add(byte(0x00), byte(0x00), byte(0x00))
+	f.Add(byte(0xff), byte(0xff), byte(0xff))
+	f.Add(byte(0x00), byte(0x00), byte(0x80))
+
+	f.Fuzz(func(t *testing.T, xl, l, h byte) {
+		p := lpsensors.DecodePressure(xl, l, h)
+		// 24bit signed count / 4096 [count/hPa]
+		if p < -2048*100*physic.Pascal || p >= 2048*100*physic.Pascal {
+			t.Fatalf("pressure out of range: %s (0x%02x%02x%02x)", p, h, l, xl)
+		}
+	})
+}
+
+func Test_DecodePressure_Negative(t *testing.T) {
+	// 0xffe000 = -8192 / 4096 = -2 hPa
+	assert.Equal(t, -200*physic.Pascal, lpsensors.DecodePressure(0x00, 0xe0, 0xff))
+	// 0x800000 = -8388608 / 4096 = -2048 hPa
+	assert.Equal(t, -2048*100*physic.Pascal, lpsensors.DecodePressure(0x00, 0x00, 0x80))
+	// 0x3f5000 = 4149248 / 4096 = 1013 hPa
+	assert.Equal(t, 1013*100*physic.Pascal, lpsensors.DecodePressure(0x00, 0x50, 0x3f))
+}
+
+func Test_DecodeTemperature_Negative(t *testing.T) {
+	lps331a := lpsensors.TemperatureScale{Offset: physic.ZeroCelsius + 425*physic.Celsius/10, CountsPerCelsius: 480}
+	lps25h := lpsensors.TemperatureScale{Offset: physic.ZeroCelsius, CountsPerCelsius: 100}
+
+	// 0x9d90 = -25200 / 480 + 42.5 = -10 degC
+	assert.Equal(t, physic.ZeroCelsius-10*physic.Celsius, lpsensors.DecodeTemperature(0x90, 0x9d, lps331a))
+	// 0xffff = -1 / 480 + 42.5, just below the offset
+	assert.Less(t, lpsensors.DecodeTemperature(0xff, 0xff, lps331a), lps331a.Offset)
+	// 0xfe0c = -500 / 100 = -5 degC
+	assert.Equal(t, physic.ZeroCelsius-5*physic.Celsius, lpsensors.DecodeTemperature(0x0c, 0xfe, lps25h))
+	// 0x8000 = -32768 / 100 = -327.68 degC
+	assert.Equal(t, physic.ZeroCelsius-32768*physic.Celsius/100, lpsensors.DecodeTemperature(0x00, 0x80, lps25h))
+}
+
+func FuzzDecodeTemperature(f *testing.F) {
+	scales := []lpsensors.TemperatureScale{
+		// LPS331A
+		{Offset: physic.ZeroCelsius + 425*physic.Celsius/10, CountsPerCelsius: 480},
+		// LPS25H
+		{Offset: physic.ZeroCelsius, CountsPerCelsius: 100},
+	}
+
+	f.Add(byte(0xd0), byte(0x6b)) // 100 degC on LPS331A
+	f.Add(byte(0x00), byte(0x00))
+	f.Add(byte(0xff), byte(0xff))
+	f.Add(byte(0x00), byte(0x80))
+	f.Add(byte(0xff), byte(0x7f))
+
+	f.Fuzz(func(t *testing.T, l, h byte) {
+		for _, s := range scales {
+			temp := lpsensors.DecodeTemperature(l, h, s)
+			// 16bit signed count / CountsPerCelsius around Offset
+			span := 32768 * physic.Celsius / physic.Temperature(s.CountsPerCelsius)
+			if temp < s.Offset-span || temp > s.Offset+span {
+				t.Fatalf("temperature out of range: %s (0x%02x%02x)", temp, h, l)
+			}
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package lpsensors
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"periph.io/x/conn/v3/physic"
+)
+
+// Env returns d as a periph physic.SenseEnv, for the pipelines built on that interface.
+// Dev itself does not implement it, since its Sense and SenseContinuous take a context.
+// Halt of the returned value stops the continuous sensing and powers the device down.
+func (d *Dev) Env() physic.SenseEnv {
+	return &envSensor{d: d}
+}
+
+var _ physic.SenseEnv = (*envSensor)(nil)
+
+// envSensor adapts Dev to physic.SenseEnv.
+type envSensor struct {
+	d *Dev
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// String returns the description of the device like Dev.String.
+func (s *envSensor) String() string {
+	return s.d.String()
+}
+
+// Sense reads the temperature and pressure like Dev.SenseEnv.
+func (s *envSensor) Sense(e *physic.Env) error {
+	return s.d.SenseEnv(e)
+}
+
+// SenseContinuous reads the temperature and pressure every interval like Dev.SenseContinuous
+// until Halt is called. The read errors are logged and the sample is skipped,
+// since the interface has no error channel.
+func (s *envSensor) SenseContinuous(interval time.Duration) (<-chan physic.Env, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return nil, s.d.wrap(errors.New("SenseContinuous: already sensing continuously"))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	values, errs := s.d.SenseContinuous(ctx, interval)
+	out := make(chan physic.Env)
+	done := make(chan struct{})
+	s.cancel, s.done = cancel, done
+
+	go func() {
+		defer close(done)
+		defer close(out)
+		for values != nil || errs != nil {
+			select {
+			case v, ok := <-values:
+				if !ok {
+					values = nil
+					continue
+				}
+				select {
+				case out <- physic.Env{Temperature: v.Temperature, Pressure: v.Pressure}:
+				case <-ctx.Done():
+				}
+			case err, ok := <-errs:
+				if !ok {
+					errs = nil
+					continue
+				}
+				s.d.mu.Lock()
+				l := s.d.logger
+				s.d.mu.Unlock()
+				l.Warn("SenseContinuous: sample skipped", "err", err)
+			}
+		}
+	}()
+	return out, nil
+}
+
+// Precision sets the resolution of the temperature and the pressure of the detected chip.
+func (s *envSensor) Precision(e *physic.Env) {
+	scale := s.d.TemperatureScale()
+	if scale.CountsPerCelsius != 0 {
+		e.Temperature = physic.Kelvin / physic.Temperature(scale.CountsPerCelsius)
+	}
+	e.Pressure = 100 * physic.Pascal / PressureCountsPerHPa
+}
+
+// Halt stops the continuous sensing, then powers the device down like Dev.Halt.
+func (s *envSensor) Halt() error {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.cancel, s.done = nil, nil
+	s.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+	return s.d.Halt()
+}
@@ -0,0 +1,118 @@
+package lpsensors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMeasurementTimeout is returned when a measurement does not complete within the allowed polls.
+var ErrMeasurementTimeout = errors.New("lps: measurement timeout")
+
+// ErrUnsupportedOption is returned when the options request a feature the chip does not have.
+var ErrUnsupportedOption = errors.New("lps: option not supported")
+
+// ErrInvalidOption is returned when the options are invalid or conflict with each other.
+var ErrInvalidOption = errors.New("lps: invalid option")
+
+// ErrSelfTest is returned when SelfTest reads a value out of the operating range of the chip.
+var ErrSelfTest = errors.New("lps: self-test failed")
+
+// ErrNoConversion is returned when the raw counts of the detected chip cannot be converted,
+// e.g. a chip registered without TemperatureScale.
+var ErrNoConversion = errors.New("lps: no conversion for the chip")
+
+// ErrUnsupportedChip is returned when WHO_AM_I does not match a supported chip.
+// The returned error is an *UnsupportedChipError carrying the value read.
+var ErrUnsupportedChip = errors.New("lps: unsupported chip")
+
+// ErrChipMismatch is returned by Verify when WHO_AM_I no longer matches the detected chip.
+// The returned error is a *ChipMismatchError carrying the values.
+var ErrChipMismatch = errors.New("lps: chip mismatch")
+
+// ErrNoResponse is returned when WHO_AM_I cannot be read or reads 0x00/0xff, e.g. no device answers the address.
+var ErrNoResponse = errors.New("lps: no response")
+
+// ErrDeviceReset is returned when the device lost its configuration, e.g. by a brownout.
+// ResetAndReinit recovers it.
+var ErrDeviceReset = errors.New("lps: device reset detected")
+
+// ErrUnsupportedAddress is returned when the I2C address is not one the device can answer on.
+var ErrUnsupportedAddress = errors.New("lps: given address not supported by device")
+
+// UnsupportedChipError reports the WHO_AM_I value of an unsupported chip.
+type UnsupportedChipError struct {
+	ID byte
+}
+
+func (e *UnsupportedChipError) Error() string {
+	return fmt.Sprintf("lps: got chip ID 0x%02x, unsupported", e.ID)
+}
+
+// Is reports ErrUnsupportedChip as the same error.
+func (e *UnsupportedChipError) Is(target error) bool {
+	return target == ErrUnsupportedChip
+}
+
+// ChipMismatchError reports the WHO_AM_I value read by Verify and the one detected.
+type ChipMismatchError struct {
+	Want, Got byte
+}
+
+func (e *ChipMismatchError) Error() string {
+	return fmt.Sprintf("lps: got chip ID 0x%02x, want 0x%02x", e.Got, e.Want)
+}
+
+// Is reports ErrChipMismatch as the same error.
+func (e *ChipMismatchError) Is(target error) bool {
+	return target == ErrChipMismatch
+}
+
+// Channel identifies a measurement channel of the device.
+type Channel int
+
+const (
+	// TemperatureChannel is the temperature output (TEMP_OUT).
+	TemperatureChannel Channel = iota
+	// PressureChannel is the pressure output (PRESS_OUT).
+	PressureChannel
+)
+
+// String satisfies the fmt.Stringer interface.
+func (c Channel) String() string {
+	switch c {
+	case TemperatureChannel:
+		return "temperature"
+	case PressureChannel:
+		return "pressure"
+	default:
+		return fmt.Sprintf("Channel(%d)", int(c))
+	}
+}
+
+// ChannelError reports a failure to read one measurement channel.
+type ChannelError struct {
+	Channel Channel
+	Err     error
+}
+
+func (e *ChannelError) Error() string {
+	return fmt.Sprintf("%s channel: %v", e.Channel, e.Err)
+}
+
+func (e *ChannelError) Unwrap() error {
+	return e.Err
+}
+
+// AddrError reports a failure of the device at an I2C address.
+type AddrError struct {
+	Addr uint16
+	Err  error
+}
+
+func (e *AddrError) Error() string {
+	return fmt.Sprintf("0x%02x: %v", e.Addr, e.Err)
+}
+
+func (e *AddrError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,86 @@
+package lpsensors
+
+import (
+	"sync"
+	"time"
+)
+
+// JitterStatsOf exposes jitterStats for tests.
+var JitterStatsOf = jitterStats
+
+// FakeClock is a clock whose timers fire as soon as they are waited on,
+// advancing the time by their duration. Its tickers fire on Tick.
+type FakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	ticks chan time.Time
+}
+
+// UseFakeClock replaces the clock of d with a FakeClock starting at start.
+func UseFakeClock(d *Dev, start time.Time) *FakeClock {
+	c := &FakeClock{now: start, ticks: make(chan time.Time)}
+	d.mu.Lock()
+	d.clock = c
+	d.mu.Unlock()
+	return c
+}
+
+// Now returns the current fake time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) NewTimer(d time.Duration) timer {
+	return &fakeTimer{c: c, d: d}
+}
+
+type fakeTimer struct {
+	c *FakeClock
+	d time.Duration
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	t.c.mu.Lock()
+	t.c.now = t.c.now.Add(t.d)
+	now := t.c.now
+	t.c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	ch <- now
+	return ch
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.d = d
+	return true
+}
+
+func (t *fakeTimer) Stop() bool {
+	return true
+}
+
+func (c *FakeClock) NewTicker(d time.Duration) ticker {
+	return fakeTicker{c: c}
+}
+
+// Tick advances the time by d and fires a ticker, blocking until one receives it.
+func (c *FakeClock) Tick(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	now := c.now
+	c.mu.Unlock()
+
+	c.ticks <- now
+}
+
+type fakeTicker struct {
+	c *FakeClock
+}
+
+func (t fakeTicker) C() <-chan time.Time {
+	return t.c.ticks
+}
+
+func (t fakeTicker) Stop() {}
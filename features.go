@@ -0,0 +1,83 @@
+package lpsensors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Features describes the optional capabilities of the detected chip.
+type Features struct {
+	// ResConf is true when the chip has the RES_CONF averaging register.
+	ResConf bool
+	// FIFO is true when the chip has a FIFO.
+	FIFO bool
+	// OneShotStatusPoll is true when Opts.OneShotStatusPoll is supported.
+	OneShotStatusPoll bool
+	// LowPower is true when Opts.LowPower is supported.
+	LowPower bool
+	// LowPassFilter is true when Opts.LowPassFilter is supported.
+	LowPassFilter bool
+	// FIFOMean is true when Opts.FIFOMean is supported.
+	FIFOMean bool
+}
+
+// Features returns the capabilities of the detected chip.
+func (d *Dev) Features() Features {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.features()
+}
+
+func (d *Dev) features() Features {
+	switch d.chipType {
+	case chipLPS331A:
+		return Features{ResConf: true}
+	case chipLPS25H:
+		return Features{ResConf: true, FIFO: true, OneShotStatusPoll: true, FIFOMean: true}
+	case chipLPS22H:
+		if d.chipID == chipLPS22HH {
+			// The FIFO registers and the low-power bit moved on LPS22HH.
+			return Features{LowPassFilter: true}
+		}
+		return Features{FIFO: true, LowPower: true, LowPassFilter: true}
+	default:
+		return Features{}
+	}
+}
+
+// hasIntPinOpts reports whether INT_H_L and PP_OD are in CTRL_REG3. They are in CTRL_REG2 on LPS22HH.
+func (d *Dev) hasIntPinOpts() bool {
+	_, ok := interruptRegMap[d.chipType]
+	return ok && d.chipID != chipLPS22HH
+}
+
+// checkFeatures returns an error listing the options the detected chip does not support.
+func (d *Dev) checkFeatures(opts *Opts) error {
+	f := d.features()
+
+	var unsupported []string
+	if !opts.Averaging.IsZero() && !f.ResConf {
+		unsupported = append(unsupported, "Averaging")
+	}
+	if opts.OneShotStatusPoll && !f.OneShotStatusPoll {
+		unsupported = append(unsupported, "OneShotStatusPoll")
+	}
+	if opts.LowPower && !f.LowPower {
+		unsupported = append(unsupported, "LowPower")
+	}
+	if opts.LowPassFilter != LPFOff && !f.LowPassFilter {
+		unsupported = append(unsupported, "LowPassFilter")
+	}
+	if opts.FIFOMean != 0 && (!f.FIFOMean || opts.Mode == OneShot) {
+		unsupported = append(unsupported, "FIFOMean")
+	}
+	if opts.IntPinOpts != (InterruptPinOpts{}) && !d.hasIntPinOpts() {
+		unsupported = append(unsupported, "IntPinOpts")
+	}
+
+	if len(unsupported) != 0 {
+		return fmt.Errorf("%w on %s: %s", ErrUnsupportedOption, d.name, strings.Join(unsupported, ", "))
+	}
+	return nil
+}
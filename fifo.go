@@ -0,0 +1,206 @@
+package lpsensors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrFIFOOverrun is returned with the samples when the FIFO was full and older samples were lost.
+var ErrFIFOOverrun = errors.New("lps: FIFO overrun")
+
+// FIFOMode is the F_MODE of FIFO_CTRL.
+type FIFOMode byte
+
+const (
+	// FIFOBypass disables the FIFO; the output registers hold the latest sample.
+	FIFOBypass FIFOMode = 0b000
+	// FIFOFIFO fills the FIFO and stops collecting when it is full.
+	FIFOFIFO FIFOMode = 0b001
+	// FIFOStream keeps the newest samples, overwriting the oldest one when full.
+	FIFOStream FIFOMode = 0b010
+	// FIFOStreamToFIFO is Stream until an interrupt event, then FIFO.
+	FIFOStreamToFIFO FIFOMode = 0b011
+	// FIFOBypassToStream is Bypass until an interrupt event, then Stream.
+	FIFOBypassToStream FIFOMode = 0b100
+	// FIFODynamicStream is Stream with a watermark-driven refill.
+	FIFODynamicStream FIFOMode = 0b110
+	// FIFOBypassToFIFO is Bypass until an interrupt event, then FIFO.
+	FIFOBypassToFIFO FIFOMode = 0b111
+)
+
+// FIFOConfig is the configuration of the FIFO.
+type FIFOConfig struct {
+	Mode FIFOMode
+	// Watermark is the FIFO level (0-31) that raises FTH_FIFO of FIFO_STATUS.
+	Watermark int
+	// StopOnWatermark limits the FIFO depth to Watermark (STOP_ON_FTH of CTRL_REG2).
+	StopOnWatermark bool
+}
+
+// FIFOStatus is the content of FIFO_STATUS.
+type FIFOStatus struct {
+	// Level is the number of unread samples.
+	Level int
+	// Watermark is true when Level has reached the watermark.
+	Watermark bool
+	// Overrun is true when the FIFO was full and a sample was lost.
+	Overrun bool
+}
+
+const (
+	lps22hFIFOCtrl   = 0x14
+	lps22hFIFOStatus = 0x26
+)
+
+// ConfigureFIFO sets up the 32-level FIFO of LPS22H.
+// The FIFO is reset through the Bypass mode, so the unread samples are lost.
+// Only supported on LPS22H.
+func (d *Dev) ConfigureFIFO(cfg FIFOConfig) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.hasLPS22HFIFO() {
+		return d.wrap(fmt.Errorf("ConfigureFIFO: %w on %s", ErrUnsupportedOption, d.name))
+	}
+	if cfg.Watermark < 0 || cfg.Watermark > 31 {
+		return d.wrap(fmt.Errorf("ConfigureFIFO: invalid watermark %d", cfg.Watermark))
+	}
+
+	ctx := context.Background()
+
+	// Bypass mode resets the FIFO.
+	if err := d.writeCommands(ctx, []byte{lps22hFIFOCtrl, 0}); err != nil {
+		return d.wrap(fmt.Errorf("ConfigureFIFO: failed to reset FIFO_CTRL(0x%x): %w", lps22hFIFOCtrl, err))
+	}
+
+	// IF_ADD_INC[4] stays set. FIFO_EN[6], STOP_ON_FTH[5]
+	base := byte(0x10)
+	if cfg.Mode != FIFOBypass {
+		base |= 1 << 6
+		if cfg.StopOnWatermark {
+			base |= 1 << 5
+		}
+	}
+	if err := d.writeCommands(ctx, []byte{d.regs.ctrl_reg2, base}); err != nil {
+		return d.wrap(fmt.Errorf("ConfigureFIFO: failed to write CTRL_REG2(0x%x): %w", d.regs.ctrl_reg2, err))
+	}
+	d.ctrl2Base = base
+
+	if cfg.Mode == FIFOBypass {
+		return nil
+	}
+	// F_MODE[7:5] WTM[4:0]
+	v := byte(cfg.Mode)<<5 | byte(cfg.Watermark)
+	if err := d.writeCommands(ctx, []byte{lps22hFIFOCtrl, v}); err != nil {
+		return d.wrap(fmt.Errorf("ConfigureFIFO: failed to write FIFO_CTRL(0x%x): %w", lps22hFIFOCtrl, err))
+	}
+	return nil
+}
+
+// FIFOStatus reads FIFO_STATUS of LPS22H.
+func (d *Dev) FIFOStatus() (FIFOStatus, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	s, err := d.fifoStatus(context.Background())
+	if err != nil {
+		return s, d.wrap(fmt.Errorf("FIFOStatus: %w", err))
+	}
+	return s, nil
+}
+
+// hasLPS22HFIFO reports whether the FIFO registers are the ones of LPS22H.
+// LPS25H has a FIFO with other registers, and LPS22HH moved them.
+func (d *Dev) hasLPS22HFIFO() bool {
+	return d.chipType == chipLPS22H && d.features().FIFO
+}
+
+func (d *Dev) fifoStatus(ctx context.Context) (FIFOStatus, error) {
+	if !d.hasLPS22HFIFO() {
+		return FIFOStatus{}, fmt.Errorf("%w on %s: FIFO", ErrUnsupportedOption, d.name)
+	}
+	b := [1]byte{}
+	if err := d.readReg(ctx, lps22hFIFOStatus, b[:]); err != nil {
+		return FIFOStatus{}, fmt.Errorf("failed to read FIFO_STATUS(0x%x): %w", lps22hFIFOStatus, err)
+	}
+	// FTH_FIFO[7] OVR[6] FSS[5:0]
+	return FIFOStatus{
+		Level:     int(b[0] & 0b111111),
+		Watermark: b[0]&(1<<7) != 0,
+		Overrun:   b[0]&(1<<6) != 0,
+	}, nil
+}
+
+// ReadFIFO drains the samples stored in the FIFO of LPS22H, oldest first.
+// When the FIFO overran, the samples are returned with an error wrapping ErrFIFOOverrun.
+func (d *Dev) ReadFIFO() ([]SensorValues, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	ctx := context.Background()
+	s, err := d.fifoStatus(ctx)
+	if err != nil {
+		return nil, d.wrap(fmt.Errorf("ReadFIFO: %w", err))
+	}
+
+	scale, err := d.temperatureScale()
+	if err != nil {
+		return nil, d.wrap(fmt.Errorf("ReadFIFO: %w", err))
+	}
+	values := make([]SensorValues, 0, s.Level)
+	for i := 0; i < s.Level; i++ {
+		// PRESS_OUT_XL, PRESS_OUT_L, PRESS_OUT_H, TEMP_OUT_L, TEMP_OUT_H
+		b := [5]byte{}
+		if err := d.readReg(ctx, d.regs.press_out, b[:]); err != nil {
+			return values, d.wrap(fmt.Errorf("ReadFIFO: failed to read sample %d: %w", i, err))
+		}
+		raw := rawTemperature(b[3], b[4])
+		values = append(values, SensorValues{
+			Temperature:    scale.Convert(raw) + d.temperatureOffset,
+			Pressure:       DecodePressure(b[0], b[1], b[2]) + d.pressureOffset - d.pressureZero,
+			RawTemperature: raw,
+			RawPressure:    rawPressure(b[0], b[1], b[2]),
+		})
+	}
+
+	if s.Overrun {
+		return values, d.wrap(fmt.Errorf("ReadFIFO: %w", ErrFIFOOverrun))
+	}
+	return values, nil
+}
+
+// fifoMeanPoints maps the number of samples of the FIFO Mean mode of LPS25H to WTM_POINT[4:0].
+var fifoMeanPoints = map[int]byte{
+	2:  0b00001,
+	4:  0b00011,
+	8:  0b00111,
+	16: 0b01111,
+	32: 0b11111,
+}
+
+// enableFIFOMean enables the FIFO Mean mode of LPS25H averaging the given number of samples.
+// PRESS_OUT then holds the running average.
+func (d *Dev) enableFIFOMean(ctx context.Context, samples int) error {
+	if !d.features().FIFOMean {
+		return fmt.Errorf("%w on %s: FIFOMean", ErrUnsupportedOption, d.name)
+	}
+	point, ok := fifoMeanPoints[samples]
+	if !ok {
+		return fmt.Errorf("invalid number of FIFO Mean samples: %d", samples)
+	}
+
+	// FIFO_EN[6]
+	const fifoEn = 1 << 6
+	if err := d.writeCommands(ctx, []byte{d.regs.ctrl_reg2, d.ctrl2Base | fifoEn}); err != nil {
+		return fmt.Errorf("failed to set FIFO_EN of CTRL_REG2(0x%x): %w", d.regs.ctrl_reg2, err)
+	}
+	d.ctrl2Base |= fifoEn
+
+	// F_MODE[7:5] = 0b110 (FIFO Mean mode), WTM_POINT[4:0]
+	const fifoCtrl = 0x2e
+	if err := d.writeCommands(ctx, []byte{fifoCtrl, 0b110<<5 | point}); err != nil {
+		return fmt.Errorf("failed to write FIFO_CTRL(0x%x): %w", fifoCtrl, err)
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package lpsensors
+
+import (
+	"context"
+	"fmt"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/physic"
+)
+
+// InterruptConfig is the configuration of the pressure threshold interrupt.
+// The chip compares the difference between the pressure and REF_P (see SetReferencePressure)
+// against Threshold, so set REF_P to the set point to be notified when the pressure moves past it.
+type InterruptConfig struct {
+	// High raises the interrupt when the pressure is above REF_P by more than Threshold.
+	High bool
+	// Low raises the interrupt when the pressure is below REF_P by more than Threshold.
+	Low bool
+	// Threshold is the magnitude of the difference (THS_P), up to about 4095 hPa.
+	Threshold physic.Pressure
+	// Latch keeps the interrupt asserted until INT_SOURCE is read (LIR).
+	Latch bool
+}
+
+// interruptRegs is the layout of the interrupt registers of a chip.
+type interruptRegs struct {
+	ctrlReg3 byte
+	// intSMask is the INT1_S/INT_S field of CTRL_REG3 selecting the signal on INT1.
+	intSMask byte
+	intCfg   byte
+	// intSource is INT_SOURCE on all but LPS22HH (see intSourceReg).
+	intSource byte
+	thsP      byte
+	// diffInIntCfg is true when DIFF_EN is in INT_CFG instead of CTRL_REG1.
+	diffInIntCfg bool
+	// drdy is set in CTRL_REG3 to signal data-ready on INT1.
+	drdy byte
+	// ctrlReg4 is CTRL_REG4 with P1_DRDY[0] routing data-ready to INT1, or zero.
+	ctrlReg4 byte
+}
+
+var interruptRegMap = map[byte]interruptRegs{
+	// INT1_S[2:0] = 0b100: data ready
+	chipLPS331A: {ctrlReg3: 0x22, intSMask: 0b111, intCfg: 0x23, intSource: 0x24, thsP: 0x25, drdy: 0b100},
+	// INT1_S[1:0] = 0b00: data signal, selected by CTRL_REG4
+	chipLPS25H: {ctrlReg3: 0x22, intSMask: 0b11, intCfg: 0x24, intSource: 0x25, thsP: 0x30, ctrlReg4: 0x23},
+	// DRDY[2], INT_S[1:0] = 0b00: data signal
+	chipLPS22H: {ctrlReg3: 0x12, intSMask: 0b11, intCfg: 0x0b, intSource: 0x25, thsP: 0x0c, diffInIntCfg: true, drdy: 0b100},
+}
+
+// intSourceReg returns the address of INT_SOURCE. It is 0x25 on LPS22HB and 0x24 on LPS22HH.
+func (d *Dev) intSourceReg() byte {
+	if d.chipID == chipLPS22HH {
+		return 0x24
+	}
+	return interruptRegMap[d.chipType].intSource
+}
+
+// InterruptSource is the cause of the pressure threshold interrupt (INT_SOURCE).
+type InterruptSource struct {
+	// Active is true when an interrupt has been generated (IA).
+	Active bool
+	// High is true when the differential pressure is above the threshold (PH).
+	High bool
+	// Low is true when the differential pressure is below the negative threshold (PL).
+	Low bool
+	// Latched is true when the interrupt was latched (InterruptConfig.Latch);
+	// the read has cleared it. Otherwise the flags follow the current pressure.
+	Latched bool
+}
+
+// InterruptSource reads INT_SOURCE to tell which event raised the pressure threshold interrupt.
+// Reading the register clears a latched interrupt.
+func (d *Dev) InterruptSource() (InterruptSource, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := interruptRegMap[d.chipType]; !ok {
+		return InterruptSource{}, d.wrap(fmt.Errorf("InterruptSource: %w on %s", ErrUnsupportedOption, d.name))
+	}
+
+	reg := d.intSourceReg()
+	b := [1]byte{}
+	if err := d.readReg(context.Background(), reg, b[:]); err != nil {
+		return InterruptSource{}, d.wrap(fmt.Errorf("InterruptSource: failed read from INT_SOURCE(0x%x): %w", reg, err))
+	}
+	// PH[0] PL[1] IA[2]
+	return InterruptSource{
+		Active:  b[0]&(1<<2) != 0,
+		High:    b[0]&(1<<0) != 0,
+		Low:     b[0]&(1<<1) != 0,
+		Latched: d.intLatched,
+	}, nil
+}
+
+// InterruptPinOpts is the electrical configuration of the INT pin (CTRL_REG3).
+type InterruptPinOpts struct {
+	// ActiveLow makes the pin active low (INT_H_L).
+	ActiveLow bool
+	// OpenDrain makes the pin open drain instead of push-pull (PP_OD).
+	OpenDrain bool
+}
+
+// bits returns INT_H_L[7] and PP_OD[6] of CTRL_REG3.
+func (o InterruptPinOpts) bits() byte {
+	var b byte
+	if o.ActiveLow {
+		b |= 1 << 7
+	}
+	if o.OpenDrain {
+		b |= 1 << 6
+	}
+	return b
+}
+
+// ConfigureInterruptPin sets the electrical configuration of the INT pin,
+// keeping the signal routed to it. INT_H_L and PP_OD are bits 7 and 6 of CTRL_REG3 on all the chips.
+func (d *Dev) ConfigureInterruptPin(opts InterruptPinOpts) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	r := interruptRegMap[d.chipType]
+	if !d.hasIntPinOpts() {
+		return d.wrap(fmt.Errorf("ConfigureInterruptPin: %w on %s", ErrUnsupportedOption, d.name))
+	}
+
+	ctrl3 := d.ctrl3&^(InterruptPinOpts{ActiveLow: true, OpenDrain: true}).bits() | opts.bits()
+	if err := d.writeCommands(context.Background(), []byte{r.ctrlReg3, ctrl3}); err != nil {
+		return d.wrap(fmt.Errorf("ConfigureInterruptPin: failed to write CTRL_REG3(0x%x): %w", r.ctrlReg3, err))
+	}
+	d.ctrl3 = ctrl3
+	// WaitForData waits for the active level.
+	d.opts.IntPinOpts = opts
+	return nil
+}
+
+// WaitForData blocks until new data is available.
+// With Opts.IntPin, the data-ready signal is routed to the INT pin (replacing the pressure
+// threshold signal) and an edge of the pin is awaited. Otherwise STATUS_REG is polled.
+func (d *Dev) WaitForData(ctx context.Context) error {
+	d.mu.Lock()
+	if d.intPin == nil {
+		defer d.mu.Unlock()
+
+		tDA, pDA := d.statusDA()
+		if err := d.waitStatus(ctx, tDA|pDA, pollOpts{}); err != nil {
+			return d.wrap(fmt.Errorf("WaitForData: %w", err))
+		}
+		return nil
+	}
+
+	err := d.enableDataReady(ctx)
+	pin, period, name := d.intPin, d.period, d.name
+	activeLow := d.opts.IntPinOpts.ActiveLow
+	d.mu.Unlock()
+	if err != nil {
+		return wrapName(name, fmt.Errorf("WaitForData: %w", err))
+	}
+
+	active := gpio.High
+	if activeLow {
+		active = gpio.Low
+	}
+	if pin.Read() == active {
+		return nil
+	}
+	for {
+		// WaitForEdge is not cancellable; check ctx every period.
+		if pin.WaitForEdge(period) {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return wrapName(name, fmt.Errorf("WaitForData: %w", err))
+		}
+	}
+}
+
+// enableDataReady routes the data-ready signal to the INT1 pin.
+func (d *Dev) enableDataReady(ctx context.Context) error {
+	if d.drdyEnabled {
+		return nil
+	}
+	r, ok := interruptRegMap[d.chipType]
+	if !ok {
+		return fmt.Errorf("%w on %s: data-ready interrupt", ErrUnsupportedOption, d.name)
+	}
+
+	if r.ctrlReg4 != 0 {
+		if err := d.writeCommands(ctx, []byte{r.ctrlReg4, 0b1}); err != nil {
+			return fmt.Errorf("failed to set P1_DRDY of CTRL_REG4(0x%x): %w", r.ctrlReg4, err)
+		}
+	}
+	ctrl3 := d.ctrl3&^r.intSMask | r.drdy
+	if err := d.writeCommands(ctx, []byte{r.ctrlReg3, ctrl3}); err != nil {
+		return fmt.Errorf("failed to write CTRL_REG3(0x%x): %w", r.ctrlReg3, err)
+	}
+	d.ctrl3 = ctrl3
+	d.drdyEnabled = true
+	return nil
+}
+
+// ConfigurePressureInterrupt sets up the pressure threshold interrupt on the INT1 (INT_DRDY) pin.
+// Disabling both High and Low turns the interrupt off.
+func (d *Dev) ConfigurePressureInterrupt(cfg InterruptConfig) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	r, ok := interruptRegMap[d.chipType]
+	if !ok {
+		return d.wrap(fmt.Errorf("ConfigurePressureInterrupt: %w on %s", ErrUnsupportedOption, d.name))
+	}
+
+	// 16 [count / hPa]
+	ths := int64(cfg.Threshold) * 16 / int64(100*physic.Pascal)
+	if ths < 0 || ths > 0xffff {
+		return d.wrap(fmt.Errorf("ConfigurePressureInterrupt: threshold %s out of range", cfg.Threshold))
+	}
+
+	enable := cfg.High || cfg.Low
+	// PH_E[0] PL_E[1] LIR[2] DIFF_EN[3]
+	var intCfg, intS byte
+	if cfg.High {
+		intCfg |= 1 << 0
+		intS |= 0b01
+	}
+	if cfg.Low {
+		intCfg |= 1 << 1
+		intS |= 0b10
+	}
+	if cfg.Latch {
+		intCfg |= 1 << 2
+	}
+	if enable && r.diffInIntCfg {
+		intCfg |= 1 << 3
+	}
+
+	ctx := context.Background()
+	if err := d.writeCommands(ctx,
+		[]byte{
+			r.thsP, byte(ths),
+			r.thsP + 1, byte(ths >> 8),
+		}); err != nil {
+		return d.wrap(fmt.Errorf("ConfigurePressureInterrupt: failed to write THS_P(0x%x): %w", r.thsP, err))
+	}
+	if err := d.writeCommands(ctx, []byte{r.intCfg, intCfg}); err != nil {
+		return d.wrap(fmt.Errorf("ConfigurePressureInterrupt: failed to write INT_CFG(0x%x): %w", r.intCfg, err))
+	}
+
+	ctrl3 := d.ctrl3&^(r.intSMask|r.drdy) | intS
+	if err := d.writeCommands(ctx, []byte{r.ctrlReg3, ctrl3}); err != nil {
+		return d.wrap(fmt.Errorf("ConfigurePressureInterrupt: failed to write CTRL_REG3(0x%x): %w", r.ctrlReg3, err))
+	}
+	d.ctrl3 = ctrl3
+	d.drdyEnabled = false
+	d.intLatched = enable && cfg.Latch
+
+	if r.diffInIntCfg {
+		return nil
+	}
+
+	// DIFF_EN[3] of CTRL_REG1 on LPS331A/LPS25H
+	const diffEn = 1 << 3
+	if enable {
+		d.ctrl1Base |= diffEn
+		d.initCmd |= diffEn
+		d.oneshotCmd |= diffEn
+	} else {
+		d.ctrl1Base &^= diffEn
+		d.initCmd &^= diffEn
+		d.oneshotCmd &^= diffEn
+	}
+	ctrl1 := d.initCmd
+	if d.oneshotMode {
+		ctrl1 = d.ctrl1Base
+	}
+	if err := d.writeCommands(ctx, []byte{d.regs.ctrl_reg1, ctrl1}); err != nil {
+		return d.wrap(fmt.Errorf("ConfigurePressureInterrupt: failed to write CTRL_REG1(0x%x): %w", d.regs.ctrl_reg1, err))
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package lpsensors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// JitterStats is the statistics of the intervals between conversions.
+type JitterStats struct {
+	// Intervals is the number of measured intervals.
+	Intervals int
+	// Mean is the average interval.
+	Mean time.Duration
+	// Jitter is the standard deviation of the intervals.
+	Jitter time.Duration
+	// Min and Max are the shortest and longest intervals.
+	Min, Max time.Duration
+}
+
+// MeasureJitter measures the intervals between n+1 successive pressure data-ready events
+// in continuous mode by polling STATUS_REG, and reports their statistics.
+// The resolution is limited by the poll interval of 1 msec and the bus latency.
+func (d *Dev) MeasureJitter(ctx context.Context, n int) (JitterStats, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.oneshotMode {
+		return JitterStats{}, d.wrap(errors.New("MeasureJitter: supported only in continuous mode"))
+	}
+	if n < 1 {
+		return JitterStats{}, d.wrap(fmt.Errorf("MeasureJitter: invalid number of intervals: %d", n))
+	}
+
+	_, pDA := d.statusDA()
+	stamps := make([]time.Time, 0, n+1)
+	datum := [3]byte{}
+	for len(stamps) < n+1 {
+		if err := d.waitStatus(ctx, pDA, pollOpts{interval: time.Millisecond}); err != nil {
+			return JitterStats{}, d.wrap(fmt.Errorf("MeasureJitter: %w", err))
+		}
+		stamps = append(stamps, d.clock.Now())
+
+		// Read PRESS_OUT to clear P_DA
+		if err := d.readReg(ctx, d.regs.press_out, datum[:]); err != nil {
+			return JitterStats{}, d.wrap(fmt.Errorf("MeasureJitter: failed to read PRESS_OUT: %w", err))
+		}
+	}
+
+	return jitterStats(stamps), nil
+}
+
+// jitterStats computes the statistics of the intervals between stamps.
+func jitterStats(stamps []time.Time) JitterStats {
+	if len(stamps) < 2 {
+		return JitterStats{}
+	}
+
+	s := JitterStats{Intervals: len(stamps) - 1}
+	var sum time.Duration
+	for i := 1; i < len(stamps); i++ {
+		iv := stamps[i].Sub(stamps[i-1])
+		sum += iv
+		if i == 1 || iv < s.Min {
+			s.Min = iv
+		}
+		if iv > s.Max {
+			s.Max = iv
+		}
+	}
+	s.Mean = sum / time.Duration(s.Intervals)
+
+	var sq float64
+	for i := 1; i < len(stamps); i++ {
+		dev := float64(stamps[i].Sub(stamps[i-1]) - s.Mean)
+		sq += dev * dev
+	}
+	s.Jitter = time.Duration(math.Sqrt(sq / float64(s.Intervals)))
+
+	return s
+}
@@ -0,0 +1,28 @@
+package lpsensors_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/walkure/go-lpsensors"
+)
+
+func Test_JitterStats(t *testing.T) {
+	// intervals: 70, 90, 70, 90 msec
+	base := time.Unix(0, 0)
+	stamps := []time.Time{base}
+	for _, iv := range []time.Duration{70, 90, 70, 90} {
+		base = base.Add(iv * time.Millisecond)
+		stamps = append(stamps, base)
+	}
+
+	s := lpsensors.JitterStatsOf(stamps)
+	assert.Equal(t, 4, s.Intervals)
+	assert.Equal(t, 80*time.Millisecond, s.Mean)
+	assert.Equal(t, 10*time.Millisecond, s.Jitter)
+	assert.Equal(t, 70*time.Millisecond, s.Min)
+	assert.Equal(t, 90*time.Millisecond, s.Max)
+
+	assert.Equal(t, lpsensors.JitterStats{}, lpsensors.JitterStatsOf(stamps[:1]))
+}
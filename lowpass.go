@@ -0,0 +1,58 @@
+package lpsensors
+
+import (
+	"context"
+	"fmt"
+)
+
+// LowPassFilter is the bandwidth of the additional low-pass filter on the pressure of LPS22H.
+type LowPassFilter int
+
+const (
+	// LPFOff disables the filter; the bandwidth is ODR/2.
+	LPFOff LowPassFilter = iota
+	// LPFODR9 sets the bandwidth to ODR/9.
+	LPFODR9
+	// LPFODR20 sets the bandwidth to ODR/20.
+	LPFODR20
+)
+
+// String satisfies the fmt.Stringer interface.
+func (f LowPassFilter) String() string {
+	switch f {
+	case LPFOff:
+		return "Off"
+	case LPFODR9:
+		return "ODR/9"
+	case LPFODR20:
+		return "ODR/20"
+	default:
+		return fmt.Sprintf("LowPassFilter(%d)", int(f))
+	}
+}
+
+// bits returns EN_LPFP[3] and LPFP_CFG[2] of CTRL_REG1.
+func (f LowPassFilter) bits() byte {
+	switch f {
+	case LPFODR9:
+		return 0b1000
+	case LPFODR20:
+		return 0b1100
+	default:
+		return 0
+	}
+}
+
+// resetLowPassFilter reads LPFP_RES(0x33) to reset the low-pass filter of LPS22H.
+// LPS22HH has no LPFP_RES; its filter is reset with the FIFO, so nothing is read there.
+func (d *Dev) resetLowPassFilter(ctx context.Context) error {
+	if d.chipID == chipLPS22HH {
+		return nil
+	}
+	const lpfpRes = 0x33
+	b := [1]byte{}
+	if err := d.readReg(ctx, lpfpRes, b[:]); err != nil {
+		return fmt.Errorf("failed to reset the low-pass filter by LPFP_RES(0x%x): %w", lpfpRes, err)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package lpsensors
+
+import (
+	"context"
+	"fmt"
+)
+
+// enableLowPower sets LC_EN[0] of RES_CONF(0x1A) on LPS22H.
+// LC_EN must be changed in power-down, so CTRL_REG1 is cleared first.
+func (d *Dev) enableLowPower(ctx context.Context) error {
+	if !d.features().LowPower {
+		return fmt.Errorf("%w on %s: LowPower", ErrUnsupportedOption, d.name)
+	}
+
+	if err := d.writeCommands(ctx,
+		[]byte{
+			d.regs.ctrl_reg1,
+			d.ctrl1Base, // turn off
+		}); err != nil {
+		return fmt.Errorf("failed to clear CTRL_REG1(0x%x): %w", d.regs.ctrl_reg1, err)
+	}
+
+	const resConf = 0x1a
+	if err := d.writeCommands(ctx,
+		[]byte{
+			resConf,
+			0b1, // LC_EN
+		}); err != nil {
+		return fmt.Errorf("failed to set LC_EN of RES_CONF(0x%x): %w", resConf, err)
+	}
+	return nil
+}
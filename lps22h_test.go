@@ -0,0 +1,908 @@
+package lpsensors_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/walkure/go-lpsensors"
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/gpio/gpiotest"
+	"periph.io/x/conn/v3/i2c/i2ctest"
+	"periph.io/x/conn/v3/physic"
+)
+
+const LPS22H_addr = 0x5c
+const LPS22H_CTRL_REG1 = 0x10
+const LPS22H_CTRL_REG2 = 0x11
+
+func init_LPS22HOps() []i2ctest.IO {
+	return []i2ctest.IO{
+		// Chip ID detection.
+		{Addr: LPS22H_addr,
+			W: []byte{0x0f},
+			R: []byte{0xb1}, //LPS22H
+		},
+		// CTRL_REG1 show
+		{Addr: LPS22H_addr,
+			W: []byte{LPS22H_CTRL_REG1},
+			R: []byte{0x00},
+		},
+		// CTRL_REG2 show
+		{Addr: LPS22H_addr,
+			W: []byte{LPS22H_CTRL_REG2},
+			R: []byte{0x10},
+		},
+		// No RES_CONF
+	}
+}
+
+func Test_LPS22H_Continuous_Measurement(t *testing.T) {
+	ops := append(init_LPS22HOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS22H_addr,
+			W:    []byte{LPS22H_CTRL_REG1, 0x22}, // ODR 10Hz, BDU
+		},
+		i2ctest.IO{
+			// STATUS_REG: no overrun
+			Addr: LPS22H_addr,
+			W:    []byte{0x27},
+			R:    []byte{0x03},
+		},
+		i2ctest.IO{
+			// Read temperature
+			Addr: LPS22H_addr,
+			W:    []byte{0x2b},       // TEMP_OUT_L, TEMP_OUT_H (IF_ADD_INC)
+			R:    []byte{0x9e, 0x0a}, // 0x0a9e = 2718 / 100 = 27.18 degC
+		},
+		i2ctest.IO{
+			// Read pressure
+			Addr: LPS22H_addr,
+			W:    []byte{0x28},             // PRESS_OUT_XL , PRESS_OUT_L, PRESS_OUT_H (IF_ADD_INC)
+			R:    []byte{0x00, 0x50, 0x3f}, // (0x3f5000=4149248) / 4096 = 1013 hPa
+		},
+	)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS22H_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.Equal(t, "LPS22H", d.ChipName())
+	assert.Equal(t, byte(0xb1), d.ChipID())
+	assert.Equal(t, "n/a", d.AveragingSummary())
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+	assert.Equal(t, int16(0x0a9e), data.RawTemperature)
+	assert.Equal(t, int32(0x3f5000), data.RawPressure)
+
+	var tc physic.Temperature
+	tc.Set("27.18C")
+
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+
+	assert.Equal(t, tc, data.Temperature)
+	assert.Equal(t, tp, data.Pressure)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS33HW_Detected_As_LPS22H(t *testing.T) {
+	// LPS33HW answers WHO_AM_I 0xb1 like LPS22HB, with the same register map and scaling.
+	ops := append(init_LPS22HOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS22H_addr,
+			W:    []byte{LPS22H_CTRL_REG1, 0x22}, // ODR 10Hz, BDU
+		},
+		i2ctest.IO{
+			// STATUS_REG: no overrun
+			Addr: LPS22H_addr,
+			W:    []byte{0x27},
+			R:    []byte{0x03},
+		},
+		i2ctest.IO{
+			// Read temperature
+			Addr: LPS22H_addr,
+			W:    []byte{0x2b},       // TEMP_OUT_L, TEMP_OUT_H (IF_ADD_INC)
+			R:    []byte{0x0c, 0xfe}, // 0xfe0c = -500 / 100 = -5 degC
+		},
+		i2ctest.IO{
+			// Read pressure
+			Addr: LPS22H_addr,
+			W:    []byte{0x28},             // PRESS_OUT_XL , PRESS_OUT_L, PRESS_OUT_H (IF_ADD_INC)
+			R:    []byte{0x00, 0x00, 0x10}, // (0x100000=1048576) / 4096 = 256 hPa
+		},
+	)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS22H_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.Equal(t, "LPS22H", d.ChipName())
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+
+	var tc physic.Temperature
+	tc.Set("-5C")
+
+	var tp physic.Pressure
+	tp.Set("25.6kPa")
+
+	assert.Equal(t, tc, data.Temperature)
+	assert.Equal(t, tp, data.Pressure)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22H_ODR(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS22HOps(), i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement at 75Hz with BDU
+			Addr: LPS22H_addr,
+			W:    []byte{LPS22H_CTRL_REG1, 0b01010010},
+		}),
+	}
+
+	if _, err := lpsensors.NewI2CWithOptions(&bus, LPS22H_addr, lpsensors.WithODR(lpsensors.ODR75Hz)); err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.NoError(t, bus.Close())
+
+	// 12.5Hz is not available on LPS22H.
+	bus = i2ctest.Playback{
+		Ops: init_LPS22HOps()[:1],
+	}
+	_, err := lpsensors.NewI2CWithOptions(&bus, LPS22H_addr, lpsensors.WithODR(lpsensors.ODR12_5Hz))
+	assert.ErrorIs(t, err, lpsensors.ErrUnsupportedOption)
+	assert.ErrorContains(t, err, "12.5Hz")
+}
+
+func Test_LPS22H_Averaging_Unsupported(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: init_LPS22HOps()[:1],
+	}
+
+	_, err := lpsensors.NewI2C(&bus, LPS22H_addr, &lpsensors.Opts{
+		Mode:      lpsensors.Continuous,
+		Averaging: lpsensors.Averaging{Pressure: 32},
+	})
+	assert.ErrorIs(t, err, lpsensors.ErrUnsupportedOption)
+	assert.ErrorContains(t, err, "Averaging")
+}
+
+func Test_LPS22H_ReferencePressure(t *testing.T) {
+	ops := append(init_LPS22HOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS22H_addr,
+			W:    []byte{LPS22H_CTRL_REG1, 0x22}, // ODR 10Hz, BDU
+		},
+		// REF_P (0x3f5000=4149248) / 4096 = 1013 hPa
+		i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x15, 0x00}}, // REF_P_XL
+		i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x16, 0x50}}, // REF_P_L
+		i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x17, 0x3f}}, // REF_P_H
+		i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x15}, R: []byte{0x00}},
+		i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x16}, R: []byte{0x50}},
+		i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x17}, R: []byte{0x3f}},
+	)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS22H_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	var ref physic.Pressure
+	ref.Set("101.3kPa")
+	if err := d.SetReferencePressure(ref); err != nil {
+		t.Fatalf("set err: %v", err)
+	}
+
+	got, err := d.ReferencePressure()
+	if err != nil {
+		t.Fatalf("get err: %v", err)
+	}
+	assert.Equal(t, ref, got)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22H_DataReady(t *testing.T) {
+	ops := append(init_LPS22HOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS22H_addr,
+			W:    []byte{LPS22H_CTRL_REG1, 0x22}, // ODR 10Hz, BDU
+		},
+		// STATUS: T_DA[1] P_DA[0] on LPS22H
+		i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x27}, R: []byte{0b01}},
+		i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x27}, R: []byte{0b10}},
+	)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS22H_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	tReady, pReady, err := d.DataReady()
+	assert.NoError(t, err)
+	assert.False(t, tReady)
+	assert.True(t, pReady)
+
+	tReady, pReady, err = d.DataReady()
+	assert.NoError(t, err)
+	assert.True(t, tReady)
+	assert.False(t, pReady)
+}
+
+func Test_LPS22H_OneShot_Measurement(t *testing.T) {
+	ops := append(init_LPS22HOps(),
+		i2ctest.IO{
+			// CTRL_REG1 power-off device
+			Addr: LPS22H_addr,
+			W:    []byte{LPS22H_CTRL_REG1, 0x00},
+		},
+		i2ctest.IO{
+			// CTRL_REG1 one-shot mode (ODR=0) and enable BDU[1] feature; no PD.
+			Addr: LPS22H_addr,
+			W:    []byte{LPS22H_CTRL_REG1, 0b00000010},
+		},
+		i2ctest.IO{
+			// CTRL_REG2 set ONE_SHOT flag keeping IF_ADD_INC
+			Addr: LPS22H_addr,
+			W:    []byte{LPS22H_CTRL_REG2, 0x11},
+		},
+		i2ctest.IO{
+			// CTRL_REG2 check ONE_SHOT flag as down (measurement done)
+			Addr: LPS22H_addr,
+			W:    []byte{LPS22H_CTRL_REG2},
+			R:    []byte{0x10},
+		},
+		i2ctest.IO{
+			// Read temperature
+			Addr: LPS22H_addr,
+			W:    []byte{0x2b}, // TEMP_OUT_L, TEMP_OUT_H (IF_ADD_INC)
+			R:    []byte{0x9e, 0x0a},
+		},
+		i2ctest.IO{
+			// Read pressure
+			Addr: LPS22H_addr,
+			W:    []byte{0x28}, // PRESS_OUT_XL , PRESS_OUT_L, PRESS_OUT_H (IF_ADD_INC)
+			R:    []byte{0x00, 0x50, 0x3f},
+		},
+	)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS22H_addr, &lpsensors.Opts{
+		Mode: lpsensors.OneShot,
+	})
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+	assert.Equal(t, tp, data.Pressure)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22H_DisableBDU(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS22HOps(), i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement: ODR 10Hz, BDU[1]=0
+			Addr: LPS22H_addr,
+			W:    []byte{LPS22H_CTRL_REG1, 0x20},
+		}),
+	}
+
+	if _, err := lpsensors.NewI2CWithOptions(&bus, LPS22H_addr, lpsensors.WithDisableBDU(true)); err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22H_DumpRegisters(t *testing.T) {
+	ops := append(init_LPS22HOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS22H_addr,
+			W:    []byte{LPS22H_CTRL_REG1, 0x22}, // ODR 10Hz, BDU
+		},
+	)
+	want := map[byte]byte{
+		0x0f: 0xb1, 0x10: 0x22, 0x11: 0x10, 0x12: 0x00,
+		0x15: 0x00, 0x16: 0x00, 0x17: 0x00, 0x1a: 0x00,
+		0x27: 0x33, 0x28: 0x00, 0x29: 0x50, 0x2a: 0x3f, 0x2b: 0x9e, 0x2c: 0x0a,
+	}
+	for _, reg := range []byte{0x0f, 0x10, 0x11, 0x12, 0x15, 0x16, 0x17, 0x1a, 0x27, 0x28, 0x29, 0x2a, 0x2b, 0x2c} {
+		ops = append(ops, i2ctest.IO{Addr: LPS22H_addr, W: []byte{reg}, R: []byte{want[reg]}})
+	}
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS22H_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	regs, err := d.DumpRegisters()
+	assert.NoError(t, err)
+	assert.Equal(t, want, regs)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22HH_DumpRegisters(t *testing.T) {
+	ops := append(init_LPS22HOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS22H_addr,
+			W:    []byte{LPS22H_CTRL_REG1, 0x22}, // ODR 10Hz, BDU
+		},
+	)
+	ops[0].R = []byte{0xb3} // LPS22HH
+	// LPS22HH has no RES_CONF at 0x1a.
+	want := map[byte]byte{
+		0x0f: 0xb3, 0x10: 0x22, 0x11: 0x10, 0x12: 0x00, 0x15: 0x00, 0x16: 0x00,
+		0x27: 0x33, 0x28: 0x00, 0x29: 0x50, 0x2a: 0x3f, 0x2b: 0x9e, 0x2c: 0x0a,
+	}
+	for _, reg := range []byte{0x0f, 0x10, 0x11, 0x12, 0x15, 0x16, 0x27, 0x28, 0x29, 0x2a, 0x2b, 0x2c} {
+		ops = append(ops, i2ctest.IO{Addr: LPS22H_addr, W: []byte{reg}, R: []byte{want[reg]}})
+	}
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS22H_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	regs, err := d.DumpRegisters()
+	assert.NoError(t, err)
+	assert.Equal(t, want, regs)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22H_LowPower(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS22HOps(),
+			i2ctest.IO{
+				// CTRL_REG1 power-down
+				Addr: LPS22H_addr,
+				W:    []byte{LPS22H_CTRL_REG1, 0x00},
+			},
+			i2ctest.IO{
+				// RES_CONF LC_EN
+				Addr: LPS22H_addr,
+				W:    []byte{0x1a, 0x01},
+			},
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS22H_addr,
+				W:    []byte{LPS22H_CTRL_REG1, 0x22}, // ODR 10Hz, BDU
+			},
+		),
+	}
+
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS22H_addr, lpsensors.WithLowPower(true))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.True(t, d.Features().LowPower)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22H_LowPassFilter(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS22HOps(),
+			i2ctest.IO{
+				// CTRL_REG1 ODR 10Hz, EN_LPFP=1, LPFP_CFG=1 (ODR/20), BDU
+				Addr: LPS22H_addr,
+				W:    []byte{LPS22H_CTRL_REG1, 0b00101110},
+			},
+			i2ctest.IO{
+				// LPFP_RES read resets the filter
+				Addr: LPS22H_addr,
+				W:    []byte{0x33},
+				R:    []byte{0x00},
+			},
+		),
+	}
+
+	if _, err := lpsensors.NewI2CWithOptions(&bus, LPS22H_addr, lpsensors.WithLowPassFilter(lpsensors.LPFODR20)); err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22H_FIFO(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS22HOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS22H_addr,
+				W:    []byte{LPS22H_CTRL_REG1, 0x22}, // ODR 10Hz, BDU
+			},
+			// FIFO_CTRL Bypass mode resets the FIFO
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x14, 0x00}},
+			// CTRL_REG2 FIFO_EN, IF_ADD_INC
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{LPS22H_CTRL_REG2, 0x50}},
+			// FIFO_CTRL Stream mode, WTM=16
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x14, 0b01010000}},
+			// FIFO_STATUS OVR, 2 samples
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x26}, R: []byte{0x42}},
+			// PRESS_OUT_XL..TEMP_OUT_H (IF_ADD_INC)
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x28}, R: []byte{0x00, 0x50, 0x3f, 0x9e, 0x0a}},
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x28}, R: []byte{0x00, 0x00, 0x10, 0x0c, 0xfe}},
+		),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS22H_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	assert.NoError(t, d.ConfigureFIFO(lpsensors.FIFOConfig{Mode: lpsensors.FIFOStream, Watermark: 16}))
+
+	values, err := d.ReadFIFO()
+	assert.ErrorIs(t, err, lpsensors.ErrFIFOOverrun)
+	if assert.Len(t, values, 2) {
+		var tc physic.Temperature
+		tc.Set("27.18C")
+		var tp physic.Pressure
+		tp.Set("101.3kPa")
+		assert.Equal(t, tc, values[0].Temperature)
+		assert.Equal(t, tp, values[0].Pressure)
+
+		tc.Set("-5C")
+		tp.Set("25.6kPa")
+		assert.Equal(t, tc, values[1].Temperature)
+		assert.Equal(t, tp, values[1].Pressure)
+	}
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22H_ConfigurePressureInterrupt(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS22HOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS22H_addr,
+				W:    []byte{LPS22H_CTRL_REG1, 0x22}, // ODR 10Hz, BDU
+			},
+			// THS_P_L, THS_P_H: 2 hPa * 16 = 32
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x0c, 0x20}},
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x0d, 0x00}},
+			// INTERRUPT_CFG PHE, PLE, DIFF_EN
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x0b, 0b1011}},
+			// CTRL_REG3 INT_S = P_high or P_low
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x12, 0b11}},
+			// INT_SOURCE IA, PL
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x25}, R: []byte{0b110}},
+		),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS22H_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	var ths physic.Pressure
+	ths.Set("200Pa")
+	assert.NoError(t, d.ConfigurePressureInterrupt(lpsensors.InterruptConfig{High: true, Low: true, Threshold: ths}))
+
+	src, err := d.InterruptSource()
+	assert.NoError(t, err)
+	assert.Equal(t, lpsensors.InterruptSource{Active: true, Low: true}, src)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22H_WaitForData(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS22HOps(),
+			// CTRL_REG3 INT_H_L (active low), PP_OD (open drain)
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x12, 0b11000000}},
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS22H_addr,
+				W:    []byte{LPS22H_CTRL_REG1, 0x22}, // ODR 10Hz, BDU
+			},
+			// CTRL_REG3 DRDY
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x12, 0b11000100}},
+		),
+	}
+
+	pin := &gpiotest.Pin{N: "INT", L: gpio.High, EdgesChan: make(chan gpio.Level, 1)}
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS22H_addr,
+		lpsensors.WithIntPin(pin),
+		lpsensors.WithIntPinOpts(lpsensors.InterruptPinOpts{ActiveLow: true, OpenDrain: true}))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	pin.EdgesChan <- gpio.Low
+	assert.NoError(t, d.WaitForData(context.TODO()))
+	// Still asserted: no need to wait for another edge.
+	assert.NoError(t, d.WaitForData(context.TODO()))
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22H_BootAndWait(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS22HOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS22H_addr,
+				W:    []byte{LPS22H_CTRL_REG1, 0x22}, // ODR 10Hz, BDU
+			},
+			// CTRL_REG2 set BOOT flag with IF_ADD_INC
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{LPS22H_CTRL_REG2, 0b10010000}},
+			// CTRL_REG2 BOOT flag cleared
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{LPS22H_CTRL_REG2}, R: []byte{0b00010000}},
+			// INT_SOURCE BOOT_STATUS still set, then cleared
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x25}, R: []byte{0b10000000}},
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x25}, R: []byte{0b00000000}},
+		),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS22H_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	assert.NoError(t, d.BootAndWait(context.Background()))
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22H_Overrun(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS22HOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS22H_addr,
+				W:    []byte{LPS22H_CTRL_REG1, 0x22},
+			},
+			i2ctest.IO{
+				// STATUS_REG: T_OR(bit 5), T_DA and P_DA
+				Addr: LPS22H_addr,
+				W:    []byte{0x27},
+				R:    []byte{0x23},
+			},
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x2b}, R: []byte{0x9e, 0x0a}},
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x28}, R: []byte{0x00, 0x50, 0x3f}},
+		),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS22H_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+	assert.True(t, data.TemperatureOverrun)
+	assert.False(t, data.PressureOverrun)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22H_ConfigureInterruptPin(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS22HOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS22H_addr,
+				W:    []byte{LPS22H_CTRL_REG1, 0x22}, // ODR 10Hz, BDU
+			},
+			// CTRL_REG3 INT_H_L[7] (active low), PP_OD[6] (open drain)
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x12, 0b11000000}},
+			// CTRL_REG3 DRDY[2] is kept
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x12, 0b11000100}},
+			// CTRL_REG3 back to push-pull, active high
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x12, 0b00000100}},
+		),
+	}
+
+	pin := &gpiotest.Pin{N: "INT", L: gpio.Low, EdgesChan: make(chan gpio.Level, 1)}
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS22H_addr, lpsensors.WithIntPin(pin))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	assert.NoError(t, d.ConfigureInterruptPin(lpsensors.InterruptPinOpts{ActiveLow: true, OpenDrain: true}))
+	// The pin is low: asserted.
+	assert.NoError(t, d.WaitForData(context.TODO()))
+	assert.NoError(t, d.ConfigureInterruptPin(lpsensors.InterruptPinOpts{}))
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22HH_Detected(t *testing.T) {
+	ops := append(init_LPS22HOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS22H_addr,
+			W:    []byte{LPS22H_CTRL_REG1, 0x22}, // ODR 10Hz, BDU
+		},
+		i2ctest.IO{
+			// STATUS_REG: no overrun
+			Addr: LPS22H_addr,
+			W:    []byte{0x27},
+			R:    []byte{0x03},
+		},
+		i2ctest.IO{
+			// Read temperature
+			Addr: LPS22H_addr,
+			W:    []byte{0x2b},       // TEMP_OUT_L, TEMP_OUT_H (IF_ADD_INC)
+			R:    []byte{0x9e, 0x0a}, // 0x0a9e = 2718 / 100 = 27.18 degC
+		},
+		i2ctest.IO{
+			// Read pressure
+			Addr: LPS22H_addr,
+			W:    []byte{0x28},             // PRESS_OUT_XL , PRESS_OUT_L, PRESS_OUT_H (IF_ADD_INC)
+			R:    []byte{0x00, 0x50, 0x3f}, // (0x3f5000=4149248) / 4096 = 1013 hPa
+		},
+	)
+	ops[0].R = []byte{0xb3} // LPS22HH
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS22H_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.Equal(t, "LPS22HH", d.ChipName())
+	assert.Equal(t, byte(0xb3), d.ChipID())
+	assert.Equal(t, lpsensors.Features{LowPassFilter: true}, d.Features())
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+
+	var tc physic.Temperature
+	tc.Set("27.18C")
+
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+
+	assert.Equal(t, tc, data.Temperature)
+	assert.Equal(t, tp, data.Pressure)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22H_SenseTemperature_BDU(t *testing.T) {
+	ops := append(init_LPS22HOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS22H_addr,
+			W:    []byte{LPS22H_CTRL_REG1, 0x22}, // ODR 10Hz, BDU
+		},
+		// TEMP_OUT, then PRESS_OUT to release the latch: 27.18 degC
+		i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x2b}, R: []byte{0x9e, 0x0a}},
+		i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x28}, R: []byte{0x00, 0x50, 0x3f}},
+		// The next sample: 0x0aa8 = 2728 / 100 = 27.28 degC
+		i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x2b}, R: []byte{0xa8, 0x0a}},
+		i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x28}, R: []byte{0x00, 0x50, 0x3f}},
+	)
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS22H_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	var tc1, tc2 physic.Temperature
+	tc1.Set("27.18C")
+	tc2.Set("27.28C")
+
+	temp, err := d.SenseTemperature(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, tc1, temp)
+	temp, err = d.SenseTemperature(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, tc2, temp)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22HH_FIFO_Unsupported(t *testing.T) {
+	ops := append(init_LPS22HOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS22H_addr,
+			W:    []byte{LPS22H_CTRL_REG1, 0x22}, // ODR 10Hz, BDU
+		},
+	)
+	ops[0].R = []byte{0xb3} // LPS22HH
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS22H_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	// The FIFO registers moved on LPS22HH; nothing is written or read.
+	assert.ErrorIs(t, d.ConfigureFIFO(lpsensors.FIFOConfig{Mode: lpsensors.FIFOStream}), lpsensors.ErrUnsupportedOption)
+	_, err = d.FIFOStatus()
+	assert.ErrorIs(t, err, lpsensors.ErrUnsupportedOption)
+	_, err = d.ReadFIFO()
+	assert.ErrorIs(t, err, lpsensors.ErrUnsupportedOption)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22HH_LowPassFilter(t *testing.T) {
+	ops := append(init_LPS22HOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS22H_addr,
+			W:    []byte{LPS22H_CTRL_REG1, 0x2a}, // ODR 10Hz, EN_LPFP, BDU
+		},
+		// No LPFP_RES(0x33) read on LPS22HH
+	)
+	ops[0].R = []byte{0xb3} // LPS22HH
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	if _, err := lpsensors.NewI2CWithOptions(&bus, LPS22H_addr, lpsensors.WithLowPassFilter(lpsensors.LPFODR9)); err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS22HH_IntPinOpts_Unsupported(t *testing.T) {
+	ops := init_LPS22HOps()
+	ops[0].R = []byte{0xb3} // LPS22HH
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	// INT_H_L and PP_OD are in CTRL_REG2 on LPS22HH.
+	_, err := lpsensors.NewI2CWithOptions(&bus, LPS22H_addr,
+		lpsensors.WithIntPinOpts(lpsensors.InterruptPinOpts{ActiveLow: true}))
+	assert.ErrorIs(t, err, lpsensors.ErrUnsupportedOption)
+	assert.ErrorContains(t, err, "IntPinOpts")
+	assert.ErrorContains(t, err, "LPS22HH")
+}
+
+func Test_LPS22H_ControlState(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS22HOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS22H_addr,
+				W:    []byte{LPS22H_CTRL_REG1, 0x22}, // ODR 10Hz, BDU
+			},
+			// CTRL_REG1: ODR 10Hz, EN_LPFP, BDU
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{LPS22H_CTRL_REG1}, R: []byte{0b00101010}},
+			// CTRL_REG2: IF_ADD_INC, ONE_SHOT
+			i2ctest.IO{Addr: LPS22H_addr, W: []byte{LPS22H_CTRL_REG2}, R: []byte{0x11}},
+		),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS22H_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	s, err := d.ControlState()
+	if err != nil {
+		t.Fatalf("ControlState err: %v", err)
+	}
+	assert.Equal(t, lpsensors.ControlState{
+		CtrlReg1:      0b00101010,
+		CtrlReg2:      0x11,
+		ODR:           lpsensors.ODR10Hz,
+		BDU:           true,
+		OneShot:       true,
+		LowPassFilter: lpsensors.LPFODR9,
+	}, s)
+	assert.NoError(t, bus.Close())
+}
+
+// txRecorder records the sub-address and the length of every read.
+type txRecorder struct {
+	*i2ctest.Playback
+	reads [][2]int
+}
+
+func (b *txRecorder) Tx(addr uint16, w, r []byte) error {
+	if len(r) != 0 {
+		b.reads = append(b.reads, [2]int{int(w[0]), len(r)})
+	}
+	return b.Playback.Tx(addr, w, r)
+}
+
+func Test_Sense_BDUReadOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		addr  uint16
+		ops   []i2ctest.IO
+		reads [][2]int
+	}{
+		{
+			name: "LPS331A",
+			addr: LPS331A_addr,
+			ops: append(init_LPS331AOps(),
+				i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1, 0xe4}},
+				i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: []byte{0x03, 0x00, 0x50, 0x3f, 0xd0, 0x6b}},
+			),
+			// STATUS_REG..TEMP_OUT_H in a single transaction
+			reads: [][2]int{{0x27 | 0x80, 6}},
+		},
+		{
+			name: "LPS25H",
+			addr: LPS25H_addr,
+			ops: append(init_LPS25HOps(),
+				i2ctest.IO{Addr: LPS25H_addr, W: []byte{LPS25H_CTRL_REG1, 0xb4}},
+				i2ctest.IO{Addr: LPS25H_addr, W: []byte{0x27 | 0x80}, R: []byte{0x03, 0x00, 0x50, 0x3f, 0x9e, 0x0a}},
+			),
+			reads: [][2]int{{0x27 | 0x80, 6}},
+		},
+		{
+			name: "LPS22H",
+			addr: LPS22H_addr,
+			ops: append(init_LPS22HOps(),
+				i2ctest.IO{Addr: LPS22H_addr, W: []byte{LPS22H_CTRL_REG1, 0x22}},
+				i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x27}, R: []byte{0x03}},
+				i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x2b}, R: []byte{0x9e, 0x0a}},
+				i2ctest.IO{Addr: LPS22H_addr, W: []byte{0x28}, R: []byte{0x00, 0x50, 0x3f}},
+			),
+			// STATUS_REG, TEMP_OUT, then PRESS_OUT ending on PRESS_OUT_H (0x2a)
+			reads: [][2]int{{0x27, 1}, {0x2b, 2}, {0x28, 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := txRecorder{Playback: &i2ctest.Playback{Ops: tt.ops}}
+			d, err := lpsensors.NewI2C(&bus, tt.addr, nil)
+			if err != nil {
+				t.Fatalf("lps err: %v", err)
+			}
+			bus.reads = nil
+
+			data := lpsensors.SensorValues{}
+			assert.NoError(t, d.Sense(context.TODO(), &data))
+			assert.Equal(t, tt.reads, bus.reads)
+			assert.NoError(t, bus.Close())
+		})
+	}
+}
@@ -0,0 +1,334 @@
+package lpsensors_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/walkure/go-lpsensors"
+	"periph.io/x/conn/v3/i2c/i2ctest"
+	"periph.io/x/conn/v3/physic"
+)
+
+const LPS25H_addr = 0x5d
+const LPS25H_CTRL_REG1 = 0x20
+const LPS25H_CTRL_REG2 = 0x21
+const LPS25H_RES_CONF = 0x10
+const LPS25H_STATUS_REG = 0x27
+
+func init_LPS25HOps() []i2ctest.IO {
+	return []i2ctest.IO{
+		// Chip ID detection.
+		{Addr: LPS25H_addr,
+			W: []byte{0x0f},
+			R: []byte{0xbd}, //LPS25H
+		},
+		// CTRL_REG1 show
+		{Addr: LPS25H_addr,
+			W: []byte{LPS25H_CTRL_REG1},
+			R: []byte{0x00},
+		},
+		// CTRL_REG2 show
+		{Addr: LPS25H_addr,
+			W: []byte{LPS25H_CTRL_REG2},
+			R: []byte{0x00},
+		},
+		// RES_CONF show
+		{Addr: LPS25H_addr,
+			W: []byte{LPS25H_RES_CONF},
+			R: []byte{0x0f},
+		},
+	}
+}
+
+func Test_LPS25H_OneShot_StatusPoll(t *testing.T) {
+
+	ops := append(init_LPS25HOps(),
+		i2ctest.IO{
+			// CTRL_REG1 power-off device
+			Addr: LPS25H_addr,
+			W:    []byte{LPS25H_CTRL_REG1, 0x00},
+		},
+		i2ctest.IO{
+			// RES_CONF set resolution
+			Addr: LPS25H_addr,
+			W:    []byte{LPS25H_RES_CONF, 0x0f},
+		},
+		i2ctest.IO{
+			// CTRL_REG1 power-on as one-shot mode and enable BDU feature.
+			Addr: LPS25H_addr,
+			W:    []byte{LPS25H_CTRL_REG1, 0b10000100},
+		},
+		i2ctest.IO{
+			// CTRL_REG2 set ONE_SHOT flag as up (start measurement)
+			Addr: LPS25H_addr,
+			W:    []byte{LPS25H_CTRL_REG2, 0x01},
+		},
+		// CTRL_REG2 is never read back, so a stuck ONE_SHOT bit does not matter.
+		i2ctest.IO{
+			// STATUS_REG: no data yet
+			Addr: LPS25H_addr,
+			W:    []byte{LPS25H_STATUS_REG},
+			R:    []byte{0x00},
+		},
+		i2ctest.IO{
+			// STATUS_REG: P_DA and T_DA are set (measurement done)
+			Addr: LPS25H_addr,
+			W:    []byte{LPS25H_STATUS_REG},
+			R:    []byte{0x03},
+		},
+		i2ctest.IO{
+			// Read STATUS_REG, PRESS_OUT_XL, PRESS_OUT_L, PRESS_OUT_H, TEMP_OUT_L, TEMP_OUT_H
+			// (0x3f5000=4149248) / 4096 = 1013 hPa
+			// 0x09c4 = 2500 / 100 = 25 degC
+			Addr: LPS25H_addr,
+			W:    []byte{0x27 | 0x80},
+			R:    []byte{0x33, 0x00, 0x50, 0x3f, 0xc4, 0x09},
+		},
+	)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS25H_addr, &lpsensors.Opts{
+		Mode:              lpsensors.OneShot,
+		OneShotStatusPoll: true,
+	})
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+
+	var tc physic.Temperature
+	tc.Set("25C")
+
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+
+	assert.Equal(t, tc, data.Temperature)
+	assert.Equal(t, tp, data.Pressure)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS25H_OneShotStatusPoll_RejectedOnLPS331A(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: init_LPS331AOps()[:1],
+	}
+
+	_, err := lpsensors.NewI2C(&bus, LPS331A_addr, &lpsensors.Opts{
+		Mode:              lpsensors.OneShot,
+		OneShotStatusPoll: true,
+	})
+	assert.ErrorIs(t, err, lpsensors.ErrUnsupportedOption)
+	assert.ErrorContains(t, err, "OneShotStatusPoll")
+	assert.ErrorContains(t, err, "LPS331A")
+}
+
+func Test_LPS25H_Reopen(t *testing.T) {
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+	)
+	ops = append(ops, init_LPS25HOps()...)
+	ops = append(ops,
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS25H_addr,
+			W:    []byte{LPS25H_CTRL_REG1, 0xb4},
+		},
+		i2ctest.IO{
+			// Read STATUS_REG, PRESS_OUT_XL, PRESS_OUT_L, PRESS_OUT_H, TEMP_OUT_L, TEMP_OUT_H
+			// (0x3f5000=4149248) / 4096 = 1013 hPa
+			// 0x09c4 = 2500 / 100 = 25 degC
+			Addr: LPS25H_addr,
+			W:    []byte{0x27 | 0x80},
+			R:    []byte{0x33, 0x00, 0x50, 0x3f, 0xc4, 0x09},
+		},
+	)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	if err := d.Reopen(0x5a); err == nil {
+		t.Fatal("expected an error for an unsupported address")
+	}
+
+	if err := d.Reopen(LPS25H_addr); err != nil {
+		t.Fatalf("reopen err: %v", err)
+	}
+	assert.Equal(t, "LPS25H", d.ChipName())
+	assert.Equal(t, byte(0xbd), d.ChipID())
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+
+	var tc physic.Temperature
+	tc.Set("25C")
+	assert.Equal(t, tc, data.Temperature)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS25H_ODR(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS25HOps(), i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement at 25Hz: PD=1 ODR=0b100 BDU=1
+			Addr: LPS25H_addr,
+			W:    []byte{LPS25H_CTRL_REG1, 0b11000100},
+		}),
+	}
+
+	if _, err := lpsensors.NewI2CWithOptions(&bus, LPS25H_addr, lpsensors.WithODR(lpsensors.ODR25Hz)); err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS25H_Averaging(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS25HOps(),
+			i2ctest.IO{
+				// RES_CONF AVGT=0b01 (Average 16) AVGP=0b10 (Average 128)
+				Addr: LPS25H_addr,
+				W:    []byte{LPS25H_RES_CONF, 0b00000110},
+			},
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS25H_addr,
+				W:    []byte{LPS25H_CTRL_REG1, 0xb4},
+			},
+		),
+	}
+
+	if _, err := lpsensors.NewI2C(&bus, LPS25H_addr, &lpsensors.Opts{
+		Mode:      lpsensors.Continuous,
+		Averaging: lpsensors.Averaging{Pressure: 128, Temperature: 16},
+	}); err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS25H_LowPassFilter_Unsupported(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: init_LPS25HOps()[:1],
+	}
+
+	_, err := lpsensors.NewI2CWithOptions(&bus, LPS25H_addr, lpsensors.WithLowPassFilter(lpsensors.LPFODR9))
+	assert.ErrorIs(t, err, lpsensors.ErrUnsupportedOption)
+	assert.ErrorContains(t, err, "LowPassFilter")
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS25H_FIFOMean(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS25HOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS25H_addr,
+				W:    []byte{LPS25H_CTRL_REG1, 0xb4},
+			},
+			// CTRL_REG2 FIFO_EN
+			i2ctest.IO{Addr: LPS25H_addr, W: []byte{LPS25H_CTRL_REG2, 0x40}},
+			// FIFO_CTRL FIFO Mean mode, 16 samples
+			i2ctest.IO{Addr: LPS25H_addr, W: []byte{0x2e, 0b11001111}},
+			// The running average is read from the output registers.
+			i2ctest.IO{Addr: LPS25H_addr, W: []byte{0x27 | 0x80}, R: []byte{0x33, 0x00, 0x50, 0x3f, 0x9e, 0x0a}},
+		),
+	}
+
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS25H_addr, lpsensors.WithFIFOMean(16))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+	assert.Equal(t, tp, data.Pressure)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS25H_FIFOMean_OneShot(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: init_LPS25HOps()[:1],
+	}
+
+	_, err := lpsensors.NewI2CWithOptions(&bus, LPS25H_addr,
+		lpsensors.WithMode(lpsensors.OneShot), lpsensors.WithFIFOMean(16))
+	assert.ErrorIs(t, err, lpsensors.ErrUnsupportedOption)
+	assert.ErrorContains(t, err, "FIFOMean")
+}
+
+func Test_LPS25H_SubZero(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS25HOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS25H_addr,
+				W:    []byte{LPS25H_CTRL_REG1, 0xb4},
+			},
+			i2ctest.IO{
+				// Read STATUS_REG, PRESS_OUT_XL, PRESS_OUT_L, PRESS_OUT_H, TEMP_OUT_L, TEMP_OUT_H
+				// 0xfc18 = -1000 / 100 = -10 degC
+				Addr: LPS25H_addr,
+				W:    []byte{0x27 | 0x80},
+				R:    []byte{0x03, 0x00, 0x50, 0x3f, 0x18, 0xfc},
+			},
+		),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS25H_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+
+	var tc physic.Temperature
+	tc.Set("-10C")
+	assert.Equal(t, tc, data.Temperature)
+	assert.Equal(t, int16(-1000), data.RawTemperature)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS25H_Range(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: init_LPS25HOps(),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS25H_addr, &lpsensors.Opts{Mode: lpsensors.OneShot})
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	minP, maxP := d.PressureRange()
+	assert.Equal(t, 260*100*physic.Pascal, minP)
+	assert.Equal(t, 1260*100*physic.Pascal, maxP)
+
+	minT, maxT := d.TemperatureRange()
+	assert.Equal(t, physic.ZeroCelsius-30*physic.Celsius, minT)
+	assert.Equal(t, physic.ZeroCelsius+105*physic.Celsius, maxT)
+	assert.NoError(t, bus.Close())
+}
@@ -2,7 +2,13 @@ package lpsensors_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/walkure/go-lpsensors"
@@ -46,15 +52,16 @@ func Test_LPS331A_Continuous_Init(t *testing.T) {
 		Ops: append(init_LPS331AOps(), i2ctest.IO{
 			// CTRL_REG1 setup for continuous measurement
 			Addr: LPS331A_addr,
-			W:    []byte{LPS331A_CTRL_REG1, 0xe0},
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
 		}),
 	}
 
-	_, err := lpsensors.NewI2C(&bus, 0x5c, nil)
+	d, err := lpsensors.NewI2C(&bus, 0x5c, nil)
 	if err != nil {
 		t.Fatalf("lps err: %v", err)
 	}
 
+	assert.Equal(t, "LPS331A{I2C:0x5c}", d.String())
 }
 
 func Test_LPS331A_Boot(t *testing.T) {
@@ -127,19 +134,15 @@ func Test_LPS331A_Continuous_Measurement(t *testing.T) {
 		i2ctest.IO{
 			// CTRL_REG1 setup for continuous measurement
 			Addr: LPS331A_addr,
-			W:    []byte{LPS331A_CTRL_REG1, 0xe0},
-		},
-		i2ctest.IO{
-			// Read temperature
-			Addr: LPS331A_addr,
-			W:    []byte{0x2b | 0x80}, // TEMP_OUT_L, TEMP_OUT_H
-			R:    []byte{0xd0, 0x6b},  // (0x6bd0 = 27600) / 480 + 42.5 = 100 degC
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
 		},
 		i2ctest.IO{
-			// Read pressure
+			// Read STATUS_REG, PRESS_OUT_XL, PRESS_OUT_L, PRESS_OUT_H, TEMP_OUT_L, TEMP_OUT_H
+			// (0x3f5000=4149248) / 4096 = 1013 hPa
+			// (0x6bd0 = 27600) / 480 + 42.5 = 100 degC
 			Addr: LPS331A_addr,
-			W:    []byte{0x28 | 0x80},      // PRESS_OUT_XL , PRESS_OUT_L, PRESS_OUT_H
-			R:    []byte{0x00, 0x50, 0x3f}, // (0x3f5000=4149248) / 4096 = 1013 hPa
+			W:    []byte{0x27 | 0x80},
+			R:    []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b},
 		},
 	)
 
@@ -154,9 +157,12 @@ func Test_LPS331A_Continuous_Measurement(t *testing.T) {
 	}
 
 	data := lpsensors.SensorValues{}
+	before := time.Now()
 	if err := d.Sense(context.TODO(), &data); err != nil {
 		t.Fatalf("sense err: %v", err)
 	}
+	assert.WithinRange(t, data.Timestamp, before, time.Now())
+	assert.Equal(t, "LPS331A{I2C:0x5c}", data.DeviceName)
 
 	var tc physic.Temperature
 	tc.Set("100C")
@@ -167,6 +173,75 @@ func Test_LPS331A_Continuous_Measurement(t *testing.T) {
 	assert.Equal(t, tc, data.Temperature)
 	assert.Equal(t, tp, data.Pressure)
 
+	// Provenance: the raw count and the scale reproduce the temperature.
+	assert.Equal(t, int16(0x6bd0), data.RawTemperature)
+	scale := d.TemperatureScale()
+	assert.Equal(t, int64(480), scale.CountsPerCelsius)
+	assert.Equal(t, data.Temperature, scale.Convert(data.RawTemperature))
+	assert.Equal(t, int32(0x3f5000), data.RawPressure)
+	assert.Equal(t, data.Pressure, physic.Pressure(data.RawPressure)*100*physic.Pascal/lpsensors.PressureCountsPerHPa)
+}
+
+func Test_LPS331A_Read(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+			i2ctest.IO{
+				// Read STATUS_REG, PRESS_OUT and TEMP_OUT: 1013 hPa, 100 degC
+				Addr: LPS331A_addr,
+				W:    []byte{0x27 | 0x80},
+				R:    []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b},
+			},
+		),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data, err := d.Read(context.TODO())
+	assert.NoError(t, err)
+
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+	assert.Equal(t, tp, data.Pressure)
+	assert.Equal(t, "LPS331A{I2C:0x5c}", data.DeviceName)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_AddrIncrement(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+			i2ctest.IO{
+				// A clone incrementing by itself: no MSB on the sub-address
+				Addr: LPS331A_addr,
+				W:    []byte{0x27},
+				R:    []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b},
+			},
+		),
+	}
+
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr, lpsensors.WithAddrIncrement(lpsensors.AddrIncAuto))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data, err := d.Read(context.TODO())
+	assert.NoError(t, err)
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+	assert.Equal(t, tp, data.Pressure)
+	assert.NoError(t, bus.Close())
 }
 
 func Test_LPS331A_OneShot_Measurement(t *testing.T) {
@@ -199,16 +274,12 @@ func Test_LPS331A_OneShot_Measurement(t *testing.T) {
 			R:    []byte{0x00},
 		},
 		i2ctest.IO{
-			// Read temperature
-			Addr: LPS331A_addr,
-			W:    []byte{0x2b | 0x80}, // TEMP_OUT_L, TEMP_OUT_H
-			R:    []byte{0xd0, 0x6b},  // (0x6bd0 = 27600) / 480 + 42.5 = 100 degC
-		},
-		i2ctest.IO{
-			// Read pressure
+			// Read STATUS_REG, PRESS_OUT_XL, PRESS_OUT_L, PRESS_OUT_H, TEMP_OUT_L, TEMP_OUT_H
+			// (0x3f5000=4149248) / 4096 = 1013 hPa
+			// (0x6bd0 = 27600) / 480 + 42.5 = 100 degC
 			Addr: LPS331A_addr,
-			W:    []byte{0x28 | 0x80},      // PRESS_OUT_XL , PRESS_OUT_L, PRESS_OUT_H
-			R:    []byte{0x00, 0x50, 0x3f}, // (0x3f5000=4149248) / 4096 = 1013 hPa
+			W:    []byte{0x27 | 0x80},
+			R:    []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b},
 		},
 	)
 
@@ -239,3 +310,2209 @@ func Test_LPS331A_OneShot_Measurement(t *testing.T) {
 	assert.Equal(t, tp, data.Pressure)
 
 }
+
+func Test_LPS331A_SenseAndSleep(t *testing.T) {
+	measure := []i2ctest.IO{
+		// CTRL_REG1 power-off device, RES_CONF, CTRL_REG1 power-on as one-shot mode
+		{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1, 0x00}},
+		{Addr: LPS331A_addr, W: []byte{LPS331A_RES_CONF, 0x7a}},
+		{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1, 0b10000100}},
+		// CTRL_REG2 ONE_SHOT up, then down
+		{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2, 0x01}},
+		{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2}, R: []byte{0x00}},
+		// Read STATUS_REG, PRESS_OUT and TEMP_OUT: 1013 hPa, 100 degC
+		{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b}},
+		// CTRL_REG1 power-off device after the measurement
+		{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1, 0x00}},
+	}
+	ops := append(init_LPS331AOps(), measure...)
+	// Powered down, the next call sets the device up again even with OneShotKeepPowered.
+	ops = append(ops, measure...)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr,
+		lpsensors.WithMode(lpsensors.OneShot), lpsensors.WithOneShotKeepPowered(true))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+	for i := 0; i < 2; i++ {
+		data := lpsensors.SensorValues{}
+		assert.NoError(t, d.SenseAndSleep(context.TODO(), &data))
+		assert.Equal(t, tp, data.Pressure)
+	}
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_SenseAndSleep_Continuous(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(), i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		}),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	assert.ErrorIs(t, d.SenseAndSleep(context.TODO(), &data), lpsensors.ErrUnsupportedOption)
+	assert.NoError(t, bus.Close())
+}
+
+// recordHandler is a slog.Handler that keeps every record with its attributes.
+type recordHandler struct {
+	mu      *sync.Mutex
+	attrs   []slog.Attr
+	records *[]map[string]string
+}
+
+func newRecordHandler() *recordHandler {
+	return &recordHandler{mu: &sync.Mutex{}, records: &[]map[string]string{}}
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	m := map[string]string{"msg": r.Message}
+	for _, a := range h.attrs {
+		m[a.Key] = a.Value.String()
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		m[a.Key] = a.Value.String()
+		return true
+	})
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	*h.records = append(*h.records, m)
+	return nil
+}
+
+func (h *recordHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &recordHandler{
+		mu:      h.mu,
+		attrs:   append(append([]slog.Attr{}, h.attrs...), attrs...),
+		records: h.records,
+	}
+}
+
+func (h *recordHandler) WithGroup(string) slog.Handler { return h }
+
+func Test_LPS331A_LogAttributes(t *testing.T) {
+	h := newRecordHandler()
+	prev := slog.Default()
+	slog.SetDefault(slog.New(h))
+	defer slog.SetDefault(prev)
+
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(), i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		}),
+	}
+
+	if _, err := lpsensors.NewI2C(&bus, 0x5c, nil); err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(*h.records) == 0 {
+		t.Fatal("no log records emitted")
+	}
+	for _, r := range *h.records {
+		assert.Equal(t, "i2c", r["bus"], r["msg"])
+		assert.Equal(t, "0x5c", r["addr"], r["msg"])
+	}
+	// Every record emitted after the chip detection carries its name.
+	last := (*h.records)[len(*h.records)-1]
+	assert.Equal(t, "LPS331A", last["chip"])
+}
+
+func Test_LPS331A_Logger(t *testing.T) {
+	h := newRecordHandler()
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(), i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		}),
+	}
+
+	if _, err := lpsensors.NewI2CWithOptions(&bus, 0x5c, lpsensors.WithLogger(slog.New(h).With("sensor", "outdoor"))); err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(*h.records) == 0 {
+		t.Fatal("no log records emitted")
+	}
+	for _, r := range *h.records {
+		assert.Equal(t, "outdoor", r["sensor"], r["msg"])
+		assert.Equal(t, "0x5c", r["addr"], r["msg"])
+	}
+}
+
+func Test_LPS331A_PrematureWarning(t *testing.T) {
+	h := newRecordHandler()
+	read := i2ctest.IO{
+		// Read STATUS_REG, PRESS_OUT and TEMP_OUT
+		Addr: LPS331A_addr,
+		W:    []byte{0x27 | 0x80},
+		R:    []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b},
+	}
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement at 1Hz
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0x94},
+			},
+			read, read,
+		),
+	}
+
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr,
+		lpsensors.WithODR(lpsensors.ODR1Hz), lpsensors.WithLogger(slog.New(h)))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	// Both reads are within a second after Init; only the first is logged.
+	for i := 0; i < 2; i++ {
+		_, err := d.Read(context.TODO())
+		assert.NoError(t, err)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var warned int
+	for _, r := range *h.records {
+		if strings.HasPrefix(r["msg"], "read within one ODR period") {
+			warned++
+			assert.Equal(t, "1s", r["Period"])
+		}
+	}
+	assert.Equal(t, 1, warned)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_ConfiguredAveraging(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: init_LPS331AOps(),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, &lpsensors.Opts{Mode: lpsensors.OneShot})
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	// RES_CONF is not written until the first measurement.
+	_, ok := d.ConfiguredAveraging()
+	assert.False(t, ok)
+	assert.Equal(t, "unknown", d.AveragingSummary())
+	assert.NoError(t, bus.Close())
+
+	bus = i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			// RES_CONF: AVGT = 64, AVGP = 256
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_RES_CONF, 0x68}},
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1, 0xe4}},
+		),
+	}
+	d, err = lpsensors.NewI2CWithOptions(&bus, LPS331A_addr,
+		lpsensors.WithAveraging(lpsensors.Averaging{Pressure: 256, Temperature: 64}))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	a, ok := d.ConfiguredAveraging()
+	assert.True(t, ok)
+	assert.Equal(t, lpsensors.Averaging{Pressure: 256, Temperature: 64}, a)
+	assert.Equal(t, "pressure avg 256 samples, temperature avg 64 samples", d.AveragingSummary())
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_SenseBestEffort_PressureFailure(t *testing.T) {
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+		i2ctest.IO{
+			// Read temperature
+			Addr: LPS331A_addr,
+			W:    []byte{0x2b | 0x80}, // TEMP_OUT_L, TEMP_OUT_H
+			R:    []byte{0xd0, 0x6b},  // (0x6bd0 = 27600) / 480 + 42.5 = 100 degC
+		},
+		// Pressure read is not in the playback, so it fails.
+	)
+
+	bus := i2ctest.Playback{
+		Ops:       ops,
+		DontPanic: true,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, 0x5c, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	err = d.SenseBestEffort(context.TODO(), &data)
+	if err == nil {
+		t.Fatal("expected an error from the pressure channel")
+	}
+
+	var cerr *lpsensors.ChannelError
+	if !errors.As(err, &cerr) {
+		t.Fatalf("expected ChannelError, got %v", err)
+	}
+	assert.Equal(t, lpsensors.PressureChannel, cerr.Channel)
+
+	var tc physic.Temperature
+	tc.Set("100C")
+	assert.Equal(t, tc, data.Temperature)
+	assert.Equal(t, physic.Pressure(0), data.Pressure)
+}
+
+func Test_LPS331A_UpdateOffsetFromReference(t *testing.T) {
+	read := []i2ctest.IO{
+		{
+			// Read STATUS_REG, PRESS_OUT_XL, PRESS_OUT_L, PRESS_OUT_H, TEMP_OUT_L, TEMP_OUT_H
+			// (0x3f5000=4149248) / 4096 = 1013 hPa
+			// (0x6bd0 = 27600) / 480 + 42.5 = 100 degC
+			Addr: LPS331A_addr,
+			W:    []byte{0x27 | 0x80},
+			R:    []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b},
+		},
+	}
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+	)
+	ops = append(ops, read...)
+	ops = append(ops, read...)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, 0x5c, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	var ref physic.Pressure
+	ref.Set("101.45kPa")
+	if err := d.UpdateOffsetFromReference(ref); err != nil {
+		t.Fatalf("update offset err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+	assert.Equal(t, ref, data.Pressure)
+}
+
+func Test_LPS331A_Zero(t *testing.T) {
+	read := func(h byte) i2ctest.IO {
+		// Read STATUS_REG, PRESS_OUT and TEMP_OUT: 0x3f5000 = 1013 hPa, 0x3f4000 = 1012 hPa
+		return i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: []byte{0x33, 0x00, h, 0x3f, 0xd0, 0x6b}}
+	}
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+			read(0x50), read(0x40), read(0x40), read(0x50),
+		),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	assert.NoError(t, d.Zero(context.TODO()))
+
+	data, err := d.Read(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, -100*physic.Pascal, data.Pressure)
+
+	r, err := d.SenseDetailed(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, -100*physic.Pascal, r.Values.Pressure)
+	assert.Equal(t, 101300*physic.Pascal, r.PressureZero)
+
+	d.ClearZero()
+	data, err = d.Read(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, 101300*physic.Pascal, data.Pressure)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_OneShot_MaxPolls(t *testing.T) {
+	stuck := i2ctest.IO{
+		// CTRL_REG2 ONE_SHOT flag stays up
+		Addr: LPS331A_addr,
+		W:    []byte{LPS331A_CTRL_REG2},
+		R:    []byte{0x01},
+	}
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 power-off device
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0x00},
+		},
+		i2ctest.IO{
+			// RES_CONF set resolution
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_RES_CONF, 0x7a},
+		},
+		i2ctest.IO{
+			// CTRL_REG1 power-on as one-shot mode and enable BDU feature.
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0b10000100},
+		},
+		i2ctest.IO{
+			// CTRL_REG2 set ONE_SHOT flag as up (start measurement)
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG2, 0x01},
+		},
+		stuck, stuck, stuck,
+	)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, 0x5c, &lpsensors.Opts{
+		Mode:            lpsensors.OneShot,
+		OneShotMaxPolls: 3,
+	})
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	err = d.Sense(context.TODO(), &data)
+	assert.ErrorIs(t, err, lpsensors.ErrMeasurementTimeout)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_SenseDetailed(t *testing.T) {
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+		i2ctest.IO{
+			// Read STATUS_REG, PRESS_OUT_XL, PRESS_OUT_L, PRESS_OUT_H, TEMP_OUT_L, TEMP_OUT_H like Sense
+			// (0x3f5000=4149248) / 4096 = 1013 hPa
+			// (0x6bd0 = 27600) / 480 + 42.5 = 100 degC
+			Addr: LPS331A_addr,
+			W:    []byte{0x27 | 0x80},
+			R:    []byte{0x23, 0x00, 0x50, 0x3f, 0xd0, 0x6b}, // P_OR
+		},
+	)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, 0x5c, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	r, err := d.SenseDetailed(context.TODO())
+	if err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+	assert.True(t, r.Values.PressureOverrun)
+	assert.False(t, r.Values.TemperatureOverrun)
+
+	assert.Equal(t, int16(0x6bd0), r.RawTemperature)
+	assert.Equal(t, int32(0x3f5000), r.RawPressure)
+	assert.Equal(t, physic.ZeroCelsius+425*physic.Celsius/10, r.TemperatureScale.Offset)
+	assert.Equal(t, int64(480), r.TemperatureScale.CountsPerCelsius)
+	assert.Equal(t, int64(4096), r.PressureCountsPerHPa)
+	assert.Equal(t, physic.Pressure(0), r.PressureOffset)
+
+	var tc physic.Temperature
+	tc.Set("100C")
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+	assert.Equal(t, tc, r.Values.Temperature)
+	assert.Equal(t, tp, r.Values.Pressure)
+	assert.Equal(t, r.Values.Temperature, r.TemperatureScale.Convert(r.RawTemperature))
+	assert.Equal(t, r.Values.Pressure, physic.Pressure(r.RawPressure)*100*physic.Pascal/physic.Pressure(r.PressureCountsPerHPa)+r.PressureOffset)
+}
+
+func Test_LPS331A_ExportImportState(t *testing.T) {
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+		// REF_P (0x3f5000=4149248) / 4096 = 1013 hPa
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x08, 0x00}}, // REF_P_XL
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x09, 0x50}}, // REF_P_L
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x0a, 0x3f}}, // REF_P_H
+	)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, 0x5c, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	var ref physic.Pressure
+	ref.Set("101.3kPa")
+	saved := lpsensors.State{
+		PressureOffset:    150 * physic.Pascal,
+		PressureZero:      101300 * physic.Pascal,
+		ReferencePressure: &ref,
+	}
+
+	b, err := json.Marshal(saved)
+	if err != nil {
+		t.Fatalf("marshal err: %v", err)
+	}
+	var restored lpsensors.State
+	if err := json.Unmarshal(b, &restored); err != nil {
+		t.Fatalf("unmarshal err: %v", err)
+	}
+
+	if err := d.ImportState(restored); err != nil {
+		t.Fatalf("import err: %v", err)
+	}
+	assert.Equal(t, saved, d.ExportState())
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_OnReading(t *testing.T) {
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+		i2ctest.IO{
+			// Read STATUS_REG, PRESS_OUT_XL, PRESS_OUT_L, PRESS_OUT_H, TEMP_OUT_L, TEMP_OUT_H
+			// (0x3f5000=4149248) / 4096 = 1013 hPa
+			// (0x6bd0 = 27600) / 480 + 42.5 = 100 degC
+			Addr: LPS331A_addr,
+			W:    []byte{0x27 | 0x80},
+			R:    []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b},
+		},
+		// The next read is not in the playback, so it fails.
+	)
+
+	bus := i2ctest.Playback{
+		Ops:       ops,
+		DontPanic: true,
+	}
+
+	var got []lpsensors.SensorValues
+	d, err := lpsensors.NewI2C(&bus, 0x5c, &lpsensors.Opts{
+		Mode: lpsensors.Continuous,
+		OnReading: func(v lpsensors.SensorValues) {
+			got = append(got, v)
+		},
+	})
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+	if err := d.Sense(context.TODO(), &lpsensors.SensorValues{}); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, data, got[0])
+	}
+}
+
+func Test_LPS331A_SenseEnv(t *testing.T) {
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+		i2ctest.IO{
+			// Read STATUS_REG, PRESS_OUT_XL, PRESS_OUT_L, PRESS_OUT_H, TEMP_OUT_L, TEMP_OUT_H
+			// (0x3f5000=4149248) / 4096 = 1013 hPa
+			// (0x6bd0 = 27600) / 480 + 42.5 = 100 degC
+			Addr: LPS331A_addr,
+			W:    []byte{0x27 | 0x80},
+			R:    []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b},
+		},
+	)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, 0x5c, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	env := physic.Env{Humidity: 50 * physic.PercentRH}
+	if err := d.SenseEnv(&env); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+
+	var tc physic.Temperature
+	tc.Set("100C")
+
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+
+	assert.Equal(t, tc, env.Temperature)
+	assert.Equal(t, tp, env.Pressure)
+	assert.Equal(t, 50*physic.PercentRH, env.Humidity)
+}
+
+func Test_LPS331A_Halt(t *testing.T) {
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+		i2ctest.IO{
+			// CTRL_REG1 power-off device
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0x00},
+		},
+		i2ctest.IO{
+			// CTRL_REG1 power-off device again
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0x00},
+		},
+	)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, 0x5c, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	assert.NoError(t, d.Halt())
+	assert.NoError(t, d.Halt())
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_NewI2CWithOptions(t *testing.T) {
+	bus := i2ctest.Playback{
+		// DO NOT SEND init command
+		Ops: init_LPS331AOps(),
+	}
+
+	d, err := lpsensors.NewI2CWithOptions(&bus, 0x5c,
+		lpsensors.WithMode(lpsensors.OneShot),
+		lpsensors.WithOneShotMaxPolls(3),
+	)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.Equal(t, "LPS331A", d.ChipName())
+	assert.NoError(t, bus.Close())
+
+	// Unspecified fields keep DefaultOpts.
+	assert.Equal(t, lpsensors.DefaultOpts(), lpsensors.NewOpts())
+	assert.Equal(t, lpsensors.OneShot, lpsensors.NewOpts(lpsensors.WithMode(lpsensors.OneShot)).Mode)
+}
+
+func Test_LPS331A_Averaging(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// RES_CONF AVGT=0b011 (Average 8) AVGP=0b0101 (Average 32)
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_RES_CONF, 0b00110101},
+			},
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+		),
+	}
+
+	if _, err := lpsensors.NewI2C(&bus, 0x5c, &lpsensors.Opts{
+		Mode:      lpsensors.Continuous,
+		Averaging: lpsensors.Averaging{Pressure: 32, Temperature: 8},
+	}); err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.NoError(t, bus.Close())
+
+	// 3 samples is not a valid setting.
+	bus = i2ctest.Playback{
+		Ops: init_LPS331AOps(),
+	}
+	_, err := lpsensors.NewI2C(&bus, 0x5c, &lpsensors.Opts{
+		Mode:      lpsensors.Continuous,
+		Averaging: lpsensors.Averaging{Pressure: 3},
+	})
+	assert.ErrorIs(t, err, lpsensors.ErrUnsupportedOption)
+}
+
+func Test_LPS331A_DataReady(t *testing.T) {
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+		// STATUS: P_DA[1] T_DA[0] on LPS331A
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27}, R: []byte{0b10}},
+	)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, 0x5c, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	tReady, pReady, err := d.DataReady()
+	assert.NoError(t, err)
+	assert.False(t, tReady)
+	assert.True(t, pReady)
+}
+
+func Test_LPS331A_SensePressure_SenseTemperature(t *testing.T) {
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+		i2ctest.IO{
+			// Read pressure only
+			Addr: LPS331A_addr,
+			W:    []byte{0x28 | 0x80},      // PRESS_OUT_XL , PRESS_OUT_L, PRESS_OUT_H
+			R:    []byte{0x00, 0x50, 0x3f}, // (0x3f5000=4149248) / 4096 = 1013 hPa
+		},
+		i2ctest.IO{
+			// Read temperature only
+			Addr: LPS331A_addr,
+			W:    []byte{0x2b | 0x80}, // TEMP_OUT_L, TEMP_OUT_H
+			R:    []byte{0xd0, 0x6b},  // (0x6bd0 = 27600) / 480 + 42.5 = 100 degC
+		},
+	)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, 0x5c, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	p, err := d.SensePressure(context.TODO())
+	if err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+	temp, err := d.SenseTemperature(context.TODO())
+	if err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+
+	var tc physic.Temperature
+	tc.Set("100C")
+
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+
+	assert.Equal(t, tc, temp)
+	assert.Equal(t, tp, p)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_SenseAveraged(t *testing.T) {
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+		i2ctest.IO{
+			// Read STATUS_REG, PRESS_OUT_XL, PRESS_OUT_L, PRESS_OUT_H, TEMP_OUT_L, TEMP_OUT_H
+			// (0x3f5000=4149248) / 4096 = 1013 hPa
+			// (0x6bd0 = 27600) / 480 + 42.5 = 100 degC
+			Addr: LPS331A_addr,
+			W:    []byte{0x27 | 0x80},
+			R:    []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b},
+		},
+		i2ctest.IO{
+			// Read STATUS_REG, PRESS_OUT_XL, PRESS_OUT_L, PRESS_OUT_H, TEMP_OUT_L, TEMP_OUT_H
+			// (0x3f7000=4157440) / 4096 = 1015 hPa
+			// (0x7620 = 30240) / 480 + 42.5 = 105.5 degC
+			Addr: LPS331A_addr,
+			W:    []byte{0x27 | 0x80},
+			R:    []byte{0x33, 0x00, 0x70, 0x3f, 0x20, 0x76},
+		},
+	)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, 0x5c, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	if err := d.SenseAveraged(context.TODO(), 2, &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+
+	var tc physic.Temperature
+	tc.Set("102.75C")
+
+	var tp physic.Pressure
+	tp.Set("101.4kPa")
+
+	assert.Equal(t, tc, data.Temperature)
+	assert.Equal(t, tp, data.Pressure)
+	assert.NoError(t, bus.Close())
+
+	assert.Error(t, d.SenseAveraged(context.TODO(), 0, &data))
+}
+
+func Test_LPS331A_DisableBDU(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(), i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement: PD=1 ODR=0b110, BDU[2]=0
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe0},
+		}),
+	}
+
+	if _, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr, lpsensors.WithDisableBDU(true)); err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.NoError(t, bus.Close())
+}
+
+func Test_UnsupportedChip(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: []i2ctest.IO{
+			// Chip ID detection.
+			{Addr: LPS331A_addr, W: []byte{0x0f}, R: []byte{0x6a}},
+		},
+	}
+
+	_, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	assert.ErrorIs(t, err, lpsensors.ErrUnsupportedChip)
+	var ce *lpsensors.UnsupportedChipError
+	if assert.ErrorAs(t, err, &ce) {
+		assert.Equal(t, byte(0x6a), ce.ID)
+	}
+}
+
+func Test_UnsupportedAddress(t *testing.T) {
+	_, err := lpsensors.NewI2C(&i2ctest.Playback{}, 0x76, nil)
+	assert.ErrorIs(t, err, lpsensors.ErrUnsupportedAddress)
+}
+
+// flakyBus fails the first failures transactions before passing them to the playback.
+type flakyBus struct {
+	i2ctest.Playback
+	failures int
+	calls    int
+}
+
+func (b *flakyBus) Tx(addr uint16, w, r []byte) error {
+	b.calls++
+	if b.failures > 0 {
+		b.failures--
+		return errors.New("nack")
+	}
+	return b.Playback.Tx(addr, w, r)
+}
+
+func Test_LPS331A_Retry(t *testing.T) {
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+	)
+
+	bus := flakyBus{Playback: i2ctest.Playback{Ops: ops}}
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr, lpsensors.WithRetry(3, time.Millisecond))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.NoError(t, bus.Close())
+
+	// A transient failure is retried.
+	bus.Playback.Ops = append(bus.Playback.Ops, i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27}, R: []byte{0x03}})
+	bus.failures = 2
+	_, _, err = d.DataReady()
+	assert.NoError(t, err)
+
+	// A persistent failure is returned once the retries are exhausted.
+	bus.failures = 10
+	bus.calls = 0
+	_, _, err = d.DataReady()
+	assert.ErrorContains(t, err, "nack")
+	assert.Equal(t, 4, bus.calls)
+}
+
+func Test_LPS331A_ConcurrentSense(t *testing.T) {
+	const n = 8
+
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+	)
+	for i := 0; i < n; i++ {
+		// Every Sense reads STATUS_REG, PRESS_OUT and TEMP_OUT in one burst.
+		ops = append(ops,
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b}},
+		)
+	}
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var data lpsensors.SensorValues
+			assert.NoError(t, d.Sense(context.TODO(), &data))
+			assert.Equal(t, tp, data.Pressure)
+		}()
+	}
+	wg.Wait()
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_SelfTest(t *testing.T) {
+	oneshot := func(press []byte) []i2ctest.IO {
+		return []i2ctest.IO{
+			// CTRL_REG1 power-off device
+			{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1, 0x00}},
+			// RES_CONF set resolution
+			{Addr: LPS331A_addr, W: []byte{LPS331A_RES_CONF, 0x7a}},
+			// CTRL_REG1 power-on as one-shot mode and enable BDU feature.
+			{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1, 0b10000100}},
+			// CTRL_REG2 set ONE_SHOT flag and check it is down
+			{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2, 0x01}},
+			{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2}, R: []byte{0x00}},
+			// Read STATUS_REG, pressure and temperature: 0 / 480 + 42.5 = 42.5 degC
+			{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: append(append([]byte{0x33}, press...), 0x00, 0x00)},
+		}
+	}
+
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+	)
+	restore := []i2ctest.IO{
+		// CTRL_REG1 power-off device, RES_CONF written back as read before
+		{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1, 0x00}},
+		{Addr: LPS331A_addr, W: []byte{LPS331A_RES_CONF, 0x40}},
+		// continuous measurement started again
+		{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1, 0xe4}},
+	}
+	// RES_CONF as set up for the continuous measurement
+	saveResConf := i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_RES_CONF}, R: []byte{0x40}}
+
+	// (0x3f5000=4149248) / 4096 = 1013 hPa
+	ops = append(ops, saveResConf)
+	ops = append(ops, oneshot([]byte{0x00, 0x50, 0x3f})...)
+	ops = append(ops, restore...)
+	// (0x100000=1048576) / 4096 = 256 hPa
+	ops = append(ops, saveResConf)
+	ops = append(ops, oneshot([]byte{0x00, 0x00, 0x10})...)
+	ops = append(ops, restore...)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	assert.NoError(t, d.SelfTest(context.TODO()))
+
+	err = d.SelfTest(context.TODO())
+	assert.ErrorIs(t, err, lpsensors.ErrSelfTest)
+	assert.ErrorContains(t, err, "pressure")
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_Offsets(t *testing.T) {
+	read := []i2ctest.IO{
+		// STATUS_REG, 1013 hPa, 100 degC
+		{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b}},
+	}
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+	)
+	ops = append(ops, read...)
+	ops = append(ops, read...)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	var po physic.Pressure
+	po.Set("-150Pa")
+	var to physic.Temperature
+	to.Set("400mK")
+
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr, lpsensors.WithOffsets(po, to))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+
+	var tc physic.Temperature
+	tc.Set("100.4C")
+	var tp physic.Pressure
+	tp.Set("101.15kPa")
+	assert.Equal(t, tc, data.Temperature)
+	assert.Equal(t, tp, data.Pressure)
+	assert.Equal(t, to, d.ExportState().TemperatureOffset)
+
+	d.SetPressureOffset(0)
+	d.SetTemperatureOffset(0)
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+	tc.Set("100C")
+	tp.Set("101.3kPa")
+	assert.Equal(t, tc, data.Temperature)
+	assert.Equal(t, tp, data.Pressure)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_LowPower_Unsupported(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: init_LPS331AOps()[:1],
+	}
+
+	_, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr, lpsensors.WithLowPower(true))
+	assert.ErrorIs(t, err, lpsensors.ErrUnsupportedOption)
+	assert.ErrorContains(t, err, "LowPower")
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_FIFO_Unsupported(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+		),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.ErrorIs(t, d.ConfigureFIFO(lpsensors.FIFOConfig{Mode: lpsensors.FIFOStream}), lpsensors.ErrUnsupportedOption)
+	_, err = d.ReadFIFO()
+	assert.ErrorIs(t, err, lpsensors.ErrUnsupportedOption)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_ConfigurePressureInterrupt(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+			// THS_P_L, THS_P_H: 2 hPa * 16 = 32
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x25, 0x20}},
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x26, 0x00}},
+			// INT_CFG PH_E, LIR
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x23, 0b101}},
+			// CTRL_REG3 INT1_S = P_high
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x22, 0b001}},
+			// CTRL_REG1 DIFF_EN
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1, 0xec}},
+			// INT_SOURCE IA, PH
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x24}, R: []byte{0b101}},
+		),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	var ths physic.Pressure
+	ths.Set("200Pa")
+	assert.NoError(t, d.ConfigurePressureInterrupt(lpsensors.InterruptConfig{High: true, Threshold: ths, Latch: true}))
+
+	src, err := d.InterruptSource()
+	assert.NoError(t, err)
+	assert.Equal(t, lpsensors.InterruptSource{Active: true, High: true, Latched: true}, src)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_WaitForData_Status(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+			// STATUS_REG T_DA only, then P_DA and T_DA
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27}, R: []byte{0x01}},
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27}, R: []byte{0x03}},
+		),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.NoError(t, d.WaitForData(context.TODO()))
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_OneShot_Canceled(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: init_LPS331AOps(),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, &lpsensors.Opts{
+		Mode: lpsensors.OneShot,
+	})
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// No transaction is started once ctx is done.
+	var e lpsensors.SensorValues
+	err = d.Sense(ctx, &e)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_OneShot_Timeout(t *testing.T) {
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 power-off device
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0x00},
+		},
+		i2ctest.IO{
+			// RES_CONF set resolution
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_RES_CONF, 0x7a},
+		},
+		i2ctest.IO{
+			// CTRL_REG1 power-on as one-shot mode and enable BDU feature.
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0b10000100},
+		},
+		i2ctest.IO{
+			// CTRL_REG2 set ONE_SHOT flag as up (start measurement)
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG2, 0x01},
+		},
+	)
+	// CTRL_REG2 ONE_SHOT flag stays up; more than the polls within the timeout.
+	for i := 0; i < 20; i++ {
+		ops = append(ops, i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2}, R: []byte{0x01}})
+	}
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr,
+		lpsensors.WithMode(lpsensors.OneShot),
+		lpsensors.WithOneShotPoll(10*time.Millisecond, 25*time.Millisecond))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	start := time.Now()
+	data := lpsensors.SensorValues{}
+	err = d.Sense(context.TODO(), &data)
+	assert.ErrorIs(t, err, lpsensors.ErrMeasurementTimeout)
+	assert.Less(t, time.Since(start), 20*10*time.Millisecond)
+}
+
+func Test_LPS331A_OneShot_Timeout_FakeClock(t *testing.T) {
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 power-off device
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0x00},
+		},
+		i2ctest.IO{
+			// RES_CONF set resolution
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_RES_CONF, 0x7a},
+		},
+		i2ctest.IO{
+			// CTRL_REG1 power-on as one-shot mode and enable BDU feature.
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0b10000100},
+		},
+		i2ctest.IO{
+			// CTRL_REG2 set ONE_SHOT flag as up (start measurement)
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG2, 0x01},
+		},
+	)
+	// CTRL_REG2 ONE_SHOT flag stays up; polled at 0, 10, 20 and 30 msec.
+	for i := 0; i < 4; i++ {
+		ops = append(ops, i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2}, R: []byte{0x01}})
+	}
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr,
+		lpsensors.WithMode(lpsensors.OneShot),
+		lpsensors.WithOneShotPoll(10*time.Millisecond, 25*time.Millisecond))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := lpsensors.UseFakeClock(d, start)
+
+	data := lpsensors.SensorValues{}
+	err = d.Sense(context.TODO(), &data)
+	assert.ErrorIs(t, err, lpsensors.ErrMeasurementTimeout)
+	assert.Equal(t, 30*time.Millisecond, clock.Now().Sub(start))
+	assert.NoError(t, bus.Close())
+}
+
+// benchmarkLPS331A runs sense b.N times on a playback bus serving reads and
+// reports the bus transactions per sense.
+func benchmarkLPS331A(b *testing.B, reads []i2ctest.IO, sense func(*lpsensors.Dev) error) {
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+	)
+	for i := 0; i < b.N; i++ {
+		ops = append(ops, reads...)
+	}
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		b.Fatalf("lps err: %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := sense(d); err != nil {
+			b.Fatalf("sense err: %v", err)
+		}
+	}
+	b.ReportMetric(float64(len(reads)), "tx/op")
+}
+
+// Sense reads STATUS_REG, PRESS_OUT and TEMP_OUT in one burst.
+func Benchmark_LPS331A_Sense(b *testing.B) {
+	reads := []i2ctest.IO{
+		{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b}},
+	}
+	var data lpsensors.SensorValues
+	benchmarkLPS331A(b, reads, func(d *lpsensors.Dev) error {
+		return d.Sense(context.TODO(), &data)
+	})
+}
+
+func Benchmark_LPS331A_SenseDetailed(b *testing.B) {
+	reads := []i2ctest.IO{
+		{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b}},
+	}
+	benchmarkLPS331A(b, reads, func(d *lpsensors.Dev) error {
+		_, err := d.SenseDetailed(context.TODO())
+		return err
+	})
+}
+
+func Test_LPS331A_OneShot_SkipSetup(t *testing.T) {
+	setup := []i2ctest.IO{
+		// CTRL_REG1 power-off device
+		{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1, 0x00}},
+		// RES_CONF set resolution
+		{Addr: LPS331A_addr, W: []byte{LPS331A_RES_CONF, 0x7a}},
+		// CTRL_REG1 power-on as one-shot mode and enable BDU feature.
+		{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1, 0b10000100}},
+	}
+	measure := []i2ctest.IO{
+		// CTRL_REG2 set ONE_SHOT flag and check it is down
+		{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2, 0x01}},
+		{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2}, R: []byte{0x00}},
+		// Read STATUS_REG, pressure and temperature
+		{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b}},
+	}
+
+	ops := append(init_LPS331AOps(), setup...)
+	ops = append(ops, measure...)
+	// The device is still set up; only ONE_SHOT is set.
+	ops = append(ops, measure...)
+	// BOOT reloads the registers, so the device is set up again.
+	ops = append(ops,
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2, 0b10000000}},
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2}, R: []byte{0x00}},
+	)
+	ops = append(ops, setup...)
+	ops = append(ops, measure...)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, &lpsensors.Opts{
+		Mode:               lpsensors.OneShot,
+		OneShotKeepPowered: true,
+	})
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	assert.NoError(t, d.Sense(context.TODO(), &data))
+	assert.NoError(t, d.Sense(context.TODO(), &data))
+	assert.NoError(t, d.Boot(context.TODO()))
+	assert.NoError(t, d.Sense(context.TODO(), &data))
+	assert.NoError(t, bus.Close())
+}
+
+// nackBus fails every transaction to addr like an absent device.
+type nackBus struct {
+	*i2ctest.Playback
+	addr uint16
+}
+
+func (b *nackBus) Tx(addr uint16, w, r []byte) error {
+	if addr == b.addr {
+		return errors.New("i2c: NACK")
+	}
+	return b.Playback.Tx(addr, w, r)
+}
+
+func Test_LPS331A_Verify(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+			// WHO_AM_I: still LPS331A, then LPS25H
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x0f}, R: []byte{0xbb}},
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x0f}, R: []byte{0xbd}},
+		),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	assert.NoError(t, d.Verify())
+
+	err = d.Verify()
+	assert.ErrorIs(t, err, lpsensors.ErrChipMismatch)
+	var mismatch *lpsensors.ChipMismatchError
+	if assert.ErrorAs(t, err, &mismatch) {
+		assert.Equal(t, byte(0xbb), mismatch.Want)
+		assert.Equal(t, byte(0xbd), mismatch.Got)
+	}
+	assert.NoError(t, bus.Close())
+}
+
+func Test_Probe(t *testing.T) {
+	// Only WHO_AM_I is read; a write fails the playback.
+	bus := i2ctest.Playback{
+		Ops: []i2ctest.IO{
+			{Addr: 0x5d, W: []byte{0x0f}, R: []byte{0xbd}},
+			{Addr: 0x5c, W: []byte{0x0f}, R: []byte{0x42}},
+		},
+	}
+
+	name, id, err := lpsensors.Probe(&bus, 0x5d)
+	assert.NoError(t, err)
+	assert.Equal(t, "LPS25H", name)
+	assert.Equal(t, byte(0xbd), id)
+
+	name, id, err = lpsensors.Probe(&bus, 0x5c)
+	assert.ErrorIs(t, err, lpsensors.ErrUnsupportedChip)
+	var unsupported *lpsensors.UnsupportedChipError
+	if assert.ErrorAs(t, err, &unsupported) {
+		assert.Equal(t, byte(0x42), unsupported.ID)
+	}
+	assert.Equal(t, "", name)
+	assert.Equal(t, byte(0x42), id)
+	assert.NoError(t, bus.Close())
+
+	_, _, err = lpsensors.Probe(&bus, 0x77)
+	assert.ErrorIs(t, err, lpsensors.ErrUnsupportedAddress)
+}
+
+func Test_NewI2CAuto(t *testing.T) {
+	// Nothing at 0x5c; LPS22H at 0x5d.
+	ops := []i2ctest.IO{
+		// WHO_AM_I probe
+		{Addr: 0x5d, W: []byte{0x0f}, R: []byte{0xb1}},
+	}
+	for _, op := range init_LPS22HOps() {
+		op.Addr = 0x5d
+		ops = append(ops, op)
+	}
+	ops = append(ops, i2ctest.IO{Addr: 0x5d, W: []byte{LPS22H_CTRL_REG1, 0x22}})
+	bus := nackBus{Playback: &i2ctest.Playback{Ops: ops}, addr: 0x5c}
+
+	d, err := lpsensors.NewI2CAuto(&bus, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.Equal(t, "LPS22H{I2C:0x5d}", d.String())
+	addr, ok := d.Address()
+	assert.True(t, ok)
+	assert.Equal(t, uint16(0x5d), addr)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_NewI2CAuto_NotFound(t *testing.T) {
+	// Nothing at 0x5c; an unknown chip at 0x5d.
+	bus := nackBus{Playback: &i2ctest.Playback{Ops: []i2ctest.IO{
+		{Addr: 0x5d, W: []byte{0x0f}, R: []byte{0x42}},
+	}}, addr: 0x5c}
+
+	_, err := lpsensors.NewI2CAuto(&bus, nil)
+	assert.ErrorIs(t, err, lpsensors.ErrUnsupportedChip)
+	assert.ErrorContains(t, err, "0x5c: failed to read WHO_AM_I(0x0f): i2c: NACK")
+	assert.NoError(t, bus.Close())
+}
+
+func Test_NewI2CGroup(t *testing.T) {
+	// LPS331A at 0x5c; nothing at 0x5d.
+	ops := append(init_LPS331AOps(), i2ctest.IO{
+		// CTRL_REG1 setup for continuous measurement
+		Addr: LPS331A_addr,
+		W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+	})
+	bus := nackBus{Playback: &i2ctest.Playback{Ops: ops}, addr: 0x5d}
+
+	devs, err := lpsensors.NewI2CGroup(&bus, []uint16{0x5c, 0x5d}, nil)
+	if assert.Len(t, devs, 2) {
+		assert.Equal(t, "LPS331A{I2C:0x5c}", devs[0].String())
+		assert.Nil(t, devs[1])
+	}
+
+	var addrErr *lpsensors.AddrError
+	if assert.ErrorAs(t, err, &addrErr) {
+		assert.Equal(t, uint16(0x5d), addrErr.Addr)
+	}
+	assert.ErrorIs(t, err, lpsensors.ErrNoResponse)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_OptsValidate(t *testing.T) {
+	var nilOpts *lpsensors.Opts
+	assert.NoError(t, nilOpts.Validate())
+	assert.NoError(t, lpsensors.DefaultOpts().Validate())
+
+	for name, tc := range map[string]struct {
+		opts *lpsensors.Opts
+		err  error
+	}{
+		"mode":           {lpsensors.NewOpts(lpsensors.WithMode(lpsensors.MeasurementMode(2))), lpsensors.ErrInvalidOption},
+		"odr":            {lpsensors.NewOpts(lpsensors.WithODR(lpsensors.ODR(99))), lpsensors.ErrInvalidOption},
+		"fifoMean":       {lpsensors.NewOpts(lpsensors.WithFIFOMean(3)), lpsensors.ErrInvalidOption},
+		"retry":          {lpsensors.NewOpts(lpsensors.WithRetry(-1, 0)), lpsensors.ErrInvalidOption},
+		"resetCheck":     {lpsensors.NewOpts(lpsensors.WithResetCheckEvery(-1)), lpsensors.ErrInvalidOption},
+		"addrIncrement":  {lpsensors.NewOpts(lpsensors.WithAddrIncrement(lpsensors.AddrIncrement(9))), lpsensors.ErrInvalidOption},
+		"oneshotODR":     {lpsensors.NewOpts(lpsensors.WithMode(lpsensors.OneShot), lpsensors.WithODR(lpsensors.ODR1Hz)), lpsensors.ErrUnsupportedOption},
+		"oneshotLPF":     {lpsensors.NewOpts(lpsensors.WithMode(lpsensors.OneShot), lpsensors.WithLowPassFilter(lpsensors.LPFODR9)), lpsensors.ErrUnsupportedOption},
+		"continuousFIFO": {lpsensors.NewOpts(lpsensors.WithFIFOMean(16)), nil},
+	} {
+		err := tc.opts.Validate()
+		if tc.err == nil {
+			assert.NoError(t, err, name)
+		} else {
+			assert.ErrorIs(t, err, tc.err, name)
+		}
+	}
+
+	// Invalid options are rejected before any bus access.
+	bus := i2ctest.Playback{}
+	_, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr, lpsensors.WithRetry(-1, 0))
+	assert.ErrorIs(t, err, lpsensors.ErrInvalidOption)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_RegisterChip(t *testing.T) {
+	lpsensors.RegisterChip(0xaa, lpsensors.ChipProfile{
+		Name:       "FAKE",
+		CtrlReg1:   0x20,
+		CtrlReg2:   0x21,
+		ODRBits:    map[lpsensors.ODR]byte{lpsensors.ODR1Hz: 0b001, lpsensors.ODR25Hz: 0b100},
+		DefaultODR: lpsensors.ODR1Hz,
+		PowerDown:  true,
+		BDU:        1 << 2,
+		TemperatureScale: lpsensors.TemperatureScale{
+			Offset:           physic.ZeroCelsius,
+			CountsPerCelsius: 100,
+		},
+	})
+	assert.Panics(t, func() { lpsensors.RegisterChip(0xbb, lpsensors.ChipProfile{}) })
+
+	bus := i2ctest.Playback{
+		Ops: []i2ctest.IO{
+			// Chip ID detection.
+			{Addr: LPS331A_addr, W: []byte{0x0f}, R: []byte{0xaa}},
+			// CTRL_REG1, CTRL_REG2 show; no RES_CONF.
+			{Addr: LPS331A_addr, W: []byte{0x20}, R: []byte{0x00}},
+			{Addr: LPS331A_addr, W: []byte{0x21}, R: []byte{0x00}},
+			// CTRL_REG1 setup for continuous measurement: PD=1 ODR=0b001 BDU=1
+			{Addr: LPS331A_addr, W: []byte{0x20, 0x94}},
+			// Read STATUS_REG, PRESS_OUT and TEMP_OUT; 1013 hPa, (0x0a9e = 2718) / 100 = 27.18 degC
+			{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: []byte{0x33, 0x00, 0x50, 0x3f, 0x9e, 0x0a}},
+		},
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.Equal(t, "FAKE", d.ChipName())
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+	var tc physic.Temperature
+	tc.Set("27.18C")
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+	assert.Equal(t, tc, data.Temperature)
+	assert.Equal(t, tp, data.Pressure)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_RegisterChip_OutRegisters(t *testing.T) {
+	lpsensors.RegisterChip(0xac, lpsensors.ChipProfile{
+		Name:       "MOVED",
+		CtrlReg1:   0x20,
+		CtrlReg2:   0x21,
+		ODRBits:    map[lpsensors.ODR]byte{lpsensors.ODR1Hz: 0b001},
+		DefaultODR: lpsensors.ODR1Hz,
+		PowerDown:  true,
+		BDU:        1 << 2,
+		TemperatureScale: lpsensors.TemperatureScale{
+			Offset:           physic.ZeroCelsius,
+			CountsPerCelsius: 100,
+		},
+		StatusReg: 0x27,
+		PressOut:  0x30,
+		TempOut:   0x3a,
+	})
+
+	bus := i2ctest.Playback{
+		Ops: []i2ctest.IO{
+			// Chip ID detection.
+			{Addr: LPS331A_addr, W: []byte{0x0f}, R: []byte{0xac}},
+			// CTRL_REG1, CTRL_REG2 show; no RES_CONF.
+			{Addr: LPS331A_addr, W: []byte{0x20}, R: []byte{0x00}},
+			{Addr: LPS331A_addr, W: []byte{0x21}, R: []byte{0x00}},
+			// CTRL_REG1 setup for continuous measurement: PD=1 ODR=0b001 BDU=1
+			{Addr: LPS331A_addr, W: []byte{0x20, 0x94}},
+			// Not contiguous: STATUS_REG, PRESS_OUT and TEMP_OUT are read one by one.
+			{Addr: LPS331A_addr, W: []byte{0x27}, R: []byte{0x03}},
+			{Addr: LPS331A_addr, W: []byte{0x30 | 0x80}, R: []byte{0x00, 0x50, 0x3f}},
+			{Addr: LPS331A_addr, W: []byte{0x3a | 0x80}, R: []byte{0x9e, 0x0a}},
+		},
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+	var tc physic.Temperature
+	tc.Set("27.18C")
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+	assert.Equal(t, tc, data.Temperature)
+	assert.Equal(t, tp, data.Pressure)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_RegisterChip_NoTemperatureScale(t *testing.T) {
+	lpsensors.RegisterChip(0xab, lpsensors.ChipProfile{
+		Name:       "NOSCALE",
+		CtrlReg1:   0x20,
+		CtrlReg2:   0x21,
+		ODRBits:    map[lpsensors.ODR]byte{lpsensors.ODR1Hz: 0b001},
+		DefaultODR: lpsensors.ODR1Hz,
+		PowerDown:  true,
+		BDU:        1 << 2,
+	})
+
+	bus := i2ctest.Playback{
+		Ops: []i2ctest.IO{
+			// Chip ID detection.
+			{Addr: LPS331A_addr, W: []byte{0x0f}, R: []byte{0xab}},
+			// CTRL_REG1, CTRL_REG2 show; no RES_CONF.
+			{Addr: LPS331A_addr, W: []byte{0x20}, R: []byte{0x00}},
+			{Addr: LPS331A_addr, W: []byte{0x21}, R: []byte{0x00}},
+			// CTRL_REG1 setup for continuous measurement: PD=1 ODR=0b001 BDU=1
+			{Addr: LPS331A_addr, W: []byte{0x20, 0x94}},
+			// Read STATUS_REG, PRESS_OUT and TEMP_OUT
+			{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: []byte{0x33, 0x00, 0x50, 0x3f, 0x9e, 0x0a}},
+		},
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	err = d.Sense(context.TODO(), &data)
+	assert.ErrorIs(t, err, lpsensors.ErrNoConversion)
+	assert.ErrorContains(t, err, "NOSCALE (0xab)")
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_SWResetAndWait(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			// CTRL_REG2 set SWRESET flag, then clear it
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2, 0b100}},
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2, 0b000}},
+			// discard PRESS and TEMP data to clear STATUS_REG
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x28 | 0x80}, R: []byte{0x00, 0x00, 0x00, 0x00, 0x00}},
+			// RES_CONF not reset yet, then reset to its default
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_RES_CONF}, R: []byte{0x00}},
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_RES_CONF}, R: []byte{0x7a}},
+		),
+	}
+
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr,
+		// DO NOT SEND init command
+		lpsensors.WithMode(lpsensors.OneShot),
+		lpsensors.WithResetWait(time.Millisecond))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	assert.NoError(t, d.SWResetAndWait(context.Background()))
+	assert.NoError(t, bus.Close())
+}
+
+func Test_WhoAmI_NoResponse(t *testing.T) {
+	bus := nackBus{Playback: &i2ctest.Playback{}, addr: LPS331A_addr}
+
+	_, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	assert.ErrorIs(t, err, lpsensors.ErrNoResponse)
+	assert.NotErrorIs(t, err, lpsensors.ErrUnsupportedChip)
+	assert.ErrorContains(t, err, "lps: no response from 0x5c")
+}
+
+func Test_WhoAmI_Zero(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: []i2ctest.IO{
+			// Chip ID detection, read once more for 0x00.
+			{Addr: LPS331A_addr, W: []byte{0x0f}, R: []byte{0x00}},
+			{Addr: LPS331A_addr, W: []byte{0x0f}, R: []byte{0x00}},
+		},
+	}
+
+	_, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	assert.ErrorIs(t, err, lpsensors.ErrNoResponse)
+	assert.NotErrorIs(t, err, lpsensors.ErrUnsupportedChip)
+	assert.EqualError(t, err, "lps: no response from 0x5c: WHO_AM_I(0x0f) reads 0x00")
+	assert.NoError(t, bus.Close())
+}
+
+func Test_WhoAmI_Reread(t *testing.T) {
+	// The first read fails; the second detects the chip.
+	bus := flakyBus{
+		Playback: i2ctest.Playback{Ops: append(init_LPS331AOps(), i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		})},
+		failures: 1,
+	}
+
+	if _, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil); err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_SensePressure_WaitDataReady(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+			// STATUS_REG not ready, then P_DA and T_DA
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27}, R: []byte{0x00}},
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27}, R: []byte{0x03}},
+			// Read pressure only: 1013 hPa
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x28 | 0x80}, R: []byte{0x00, 0x50, 0x3f}},
+			// STATUS_REG ready, then read temperature only: 100 degC
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27}, R: []byte{0x03}},
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x2b | 0x80}, R: []byte{0xd0, 0x6b}},
+		),
+	}
+
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr, lpsensors.WithWaitDataReady(true))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	p, err := d.SensePressure(context.TODO())
+	assert.NoError(t, err)
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+	assert.Equal(t, tp, p)
+
+	temp, err := d.SenseTemperature(context.TODO())
+	assert.NoError(t, err)
+	var tc physic.Temperature
+	tc.Set("100C")
+	assert.Equal(t, tc, temp)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_WaitDataReady(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+			// STATUS_REG not ready, T_DA only, then P_DA and T_DA
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27}, R: []byte{0x00}},
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27}, R: []byte{0x00}},
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27}, R: []byte{0x01}},
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27}, R: []byte{0x03}},
+			// Read STATUS_REG, pressure and temperature
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: []byte{0x03, 0x00, 0x50, 0x3f, 0xd0, 0x6b}},
+		),
+	}
+
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr, lpsensors.WithWaitDataReady(true))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+	assert.Equal(t, tp, data.Pressure)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_OneShot_PowerCycle(t *testing.T) {
+	measure := []i2ctest.IO{
+		// CTRL_REG1 power-off device
+		{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1, 0x00}},
+		// RES_CONF set resolution
+		{Addr: LPS331A_addr, W: []byte{LPS331A_RES_CONF, 0x7a}},
+		// CTRL_REG1 power-on as one-shot mode and enable BDU feature.
+		{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1, 0b10000100}},
+		// CTRL_REG2 set ONE_SHOT flag and check it is down
+		{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2, 0x01}},
+		{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2}, R: []byte{0x00}},
+		// Read STATUS_REG, pressure and temperature
+		{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b}},
+	}
+
+	// Without OneShotKeepPowered, every measurement starts from powering down.
+	ops := append(init_LPS331AOps(), measure...)
+	ops = append(ops, measure...)
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, &lpsensors.Opts{
+		Mode: lpsensors.OneShot,
+	})
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	assert.NoError(t, d.Sense(context.TODO(), &data))
+	assert.NoError(t, d.Sense(context.TODO(), &data))
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_Overrun(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+			i2ctest.IO{
+				// STATUS_REG: T_OR(bit 4), P_DA and T_DA
+				Addr: LPS331A_addr,
+				W:    []byte{0x27 | 0x80},
+				R:    []byte{0x13, 0x00, 0x50, 0x3f, 0xd0, 0x6b},
+			},
+		),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+	assert.True(t, data.TemperatureOverrun)
+	assert.False(t, data.PressureOverrun)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_ResetAndReinit(t *testing.T) {
+	ops := append(init_LPS331AOps(),
+		i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+		// SWReset
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2, 0b100}},
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2, 0b000}},
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x28 | 0x80}, R: []byte{0x00, 0x00, 0x00, 0x00, 0x00}},
+	)
+	// The chip is detected and initialized again with the current options.
+	ops = append(ops, init_LPS331AOps()...)
+	ops = append(ops,
+		i2ctest.IO{
+			// CTRL_REG1 is written again: the shadow is cleared
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		},
+	)
+	// And then with OneShot, which writes nothing.
+	ops = append(ops,
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2, 0b100}},
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2, 0b000}},
+		i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x28 | 0x80}, R: []byte{0x00, 0x00, 0x00, 0x00, 0x00}},
+	)
+	ops = append(ops, init_LPS331AOps()...)
+
+	bus := i2ctest.Playback{
+		Ops: ops,
+	}
+
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr, lpsensors.WithResetWait(time.Millisecond))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	if err := d.ResetAndReinit(context.Background(), nil); err != nil {
+		t.Fatalf("reset err: %v", err)
+	}
+	if err := d.ResetAndReinit(context.Background(), &lpsensors.Opts{
+		Mode:      lpsensors.OneShot,
+		ResetWait: time.Millisecond,
+	}); err != nil {
+		t.Fatalf("reset err: %v", err)
+	}
+	assert.Equal(t, "LPS331A{I2C:0x5c}", d.String())
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_ConfigureInterruptPin(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			// CTRL_REG3 INT_H_L[7] (active low), push-pull
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x22, 0b10000000}},
+		),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, &lpsensors.Opts{Mode: lpsensors.OneShot})
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	assert.NoError(t, d.ConfigureInterruptPin(lpsensors.InterruptPinOpts{ActiveLow: true}))
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_OnSample(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+			i2ctest.IO{
+				// Read STATUS_REG, PRESS_OUT_XL, PRESS_OUT_L, PRESS_OUT_H, TEMP_OUT_L, TEMP_OUT_H
+				Addr: LPS331A_addr,
+				W:    []byte{0x27 | 0x80},
+				R:    []byte{0x03, 0x00, 0x50, 0x3f, 0xd0, 0x6b},
+			},
+		),
+	}
+
+	var raws []lpsensors.RawSample
+	var values []lpsensors.SensorValues
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr,
+		lpsensors.WithOnSample(func(raw lpsensors.RawSample, v lpsensors.SensorValues) {
+			raws = append(raws, raw)
+			values = append(values, v)
+		}))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+
+	assert.Equal(t, []lpsensors.RawSample{{Temperature: 0x6bd0, Pressure: 0x3f5000}}, raws)
+	assert.Equal(t, []lpsensors.SensorValues{data}, values)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_SkipInit(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			// CTRL_REG3 written by hand before Init
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x22, 0b10000000}},
+			// Init keeps the CTRL_REG3 configuration
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x22, 0b10000000}},
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement by Init
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+		),
+	}
+
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr, lpsensors.WithSkipInit(true))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.Equal(t, "LPS331A", d.ChipName())
+
+	assert.NoError(t, d.ConfigureInterruptPin(lpsensors.InterruptPinOpts{ActiveLow: true}))
+	assert.NoError(t, d.Init(nil))
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_SkipInit_InitOpts(t *testing.T) {
+	bus := &regBus{}
+	bus.regs[0x0f] = 0xbb // WHO_AM_I
+	bus.regs[0x27] = 0x03 // STATUS_REG: T_DA and P_DA
+
+	d, err := lpsensors.NewI2CWithOptions(bus, LPS331A_addr, lpsensors.WithSkipInit(true))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	// The options are validated against the chip before anything is written.
+	err = d.Init(&lpsensors.Opts{LowPower: true})
+	assert.ErrorIs(t, err, lpsensors.ErrUnsupportedOption)
+	assert.Equal(t, byte(0), bus.regs[LPS331A_CTRL_REG1])
+
+	var readings int
+	assert.NoError(t, d.Init(&lpsensors.Opts{
+		Mode:      lpsensors.Continuous,
+		ODR:       lpsensors.ODR1Hz,
+		OnReading: func(lpsensors.SensorValues) { readings++ },
+	}))
+	// PD, ODR 1Hz, BDU
+	assert.Equal(t, byte(0x94), bus.regs[LPS331A_CTRL_REG1])
+	assert.Equal(t, time.Second, d.SamplePeriod())
+
+	var v lpsensors.SensorValues
+	assert.NoError(t, d.Sense(context.TODO(), &v))
+	assert.Equal(t, 1, readings)
+}
+
+func Test_NewI2CContext_Canceled(t *testing.T) {
+	bus := i2ctest.Playback{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := lpsensors.NewI2CContext(ctx, &bus, LPS331A_addr, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NotErrorIs(t, err, lpsensors.ErrNoResponse)
+	// Nothing is sent.
+	assert.NoError(t, bus.Close())
+}
+
+func Test_NewI2CContext(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(), i2ctest.IO{
+			// CTRL_REG1 setup for continuous measurement
+			Addr: LPS331A_addr,
+			W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+		}),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	d, err := lpsensors.NewI2CContext(ctx, &bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.Equal(t, "LPS331A", d.ChipName())
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_ControlState(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+			// CTRL_REG1: PD, ODR 12.5Hz, BDU
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1}, R: []byte{0xe4}},
+			// CTRL_REG2
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG2}, R: []byte{0x00}},
+			// RES_CONF: AVGT 128, AVGP 512
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_RES_CONF}, R: []byte{0x7a}},
+		),
+	}
+
+	d, err := lpsensors.NewI2C(&bus, LPS331A_addr, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	s, err := d.ControlState()
+	if err != nil {
+		t.Fatalf("ControlState err: %v", err)
+	}
+	assert.Equal(t, lpsensors.ControlState{
+		CtrlReg1:  0xe4,
+		CtrlReg2:  0x00,
+		ResConf:   0x7a,
+		ODR:       lpsensors.ODR12_5Hz,
+		BDU:       true,
+		Averaging: lpsensors.Averaging{Pressure: 512, Temperature: 128},
+	}, s)
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_ResetCheck(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+			// 1st Sense: no check
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: []byte{0x03, 0x00, 0x50, 0x3f, 0xd0, 0x6b}},
+			// 2nd Sense: CTRL_REG1 holds the configuration
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1}, R: []byte{0xe4}},
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: []byte{0x03, 0x00, 0x50, 0x3f, 0xd0, 0x6b}},
+			// 3rd Sense: no check
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{0x27 | 0x80}, R: []byte{0x03, 0x00, 0x50, 0x3f, 0xd0, 0x6b}},
+			// 4th Sense: CTRL_REG1 is back to its power-on value
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1}, R: []byte{0x00}},
+		),
+	}
+
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr, lpsensors.WithResetCheckEvery(2))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	for i := 0; i < 3; i++ {
+		if err := d.Sense(context.TODO(), &data); err != nil {
+			t.Fatalf("sense %d err: %v", i, err)
+		}
+	}
+	err = d.Sense(context.TODO(), &data)
+	assert.ErrorIs(t, err, lpsensors.ErrDeviceReset)
+	assert.ErrorContains(t, err, "reads 0x00, not 0xe4")
+	assert.NoError(t, bus.Close())
+}
+
+func Test_LPS331A_SenseBestEffort_ResetCheck(t *testing.T) {
+	bus := i2ctest.Playback{
+		Ops: append(init_LPS331AOps(),
+			i2ctest.IO{
+				// CTRL_REG1 setup for continuous measurement
+				Addr: LPS331A_addr,
+				W:    []byte{LPS331A_CTRL_REG1, 0xe4},
+			},
+			// CTRL_REG1 is back to its power-on value; no channel is read.
+			i2ctest.IO{Addr: LPS331A_addr, W: []byte{LPS331A_CTRL_REG1}, R: []byte{0x00}},
+		),
+	}
+
+	d, err := lpsensors.NewI2CWithOptions(&bus, LPS331A_addr, lpsensors.WithResetCheckEvery(1))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	err = d.SenseBestEffort(context.TODO(), &data)
+	assert.ErrorIs(t, err, lpsensors.ErrDeviceReset)
+	assert.NoError(t, bus.Close())
+}
+
+// regBus is a register file answering every transaction, for tests whose order of
+// transactions is not fixed.
+type regBus struct {
+	i2ctest.Playback
+	mu   sync.Mutex
+	regs [0x80]byte
+}
+
+func (b *regBus) Tx(addr uint16, w, r []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	reg := w[0] & 0x7f
+	for i, v := range w[1:] {
+		b.regs[int(reg)+i] = v
+	}
+	for i := range r {
+		r[i] = b.regs[int(reg)+i]
+	}
+	return nil
+}
+
+func Test_LPS331A_SenseDuringReopen(t *testing.T) {
+	bus := &regBus{}
+	bus.regs[0x0f] = 0xbb // WHO_AM_I
+	bus.regs[0x27] = 0x03 // STATUS_REG: T_DA and P_DA
+
+	var readings int
+	opts := lpsensors.DefaultOpts()
+	opts.OnReading = func(lpsensors.SensorValues) { readings++ }
+	d, err := lpsensors.NewI2C(bus, LPS331A_addr, opts)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			assert.NoError(t, d.Reopen(LPS331A_addr))
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		var v lpsensors.SensorValues
+		assert.NoError(t, d.Sense(context.TODO(), &v))
+		assert.Equal(t, "LPS331A{I2C:0x5c}", d.String())
+	}
+	wg.Wait()
+	assert.Equal(t, 20, readings)
+}
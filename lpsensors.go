@@ -7,8 +7,11 @@ import (
 	"time"
 
 	"log/slog"
+	"strings"
+	"sync"
 
 	"periph.io/x/conn/v3"
+	"periph.io/x/conn/v3/gpio"
 	"periph.io/x/conn/v3/i2c"
 	"periph.io/x/conn/v3/physic"
 	"periph.io/x/conn/v3/spi"
@@ -17,37 +20,251 @@ import (
 const (
 	chipLPS331A = 0xbb
 	chipLPS25H  = 0xbd
-	chipLPS22H  = 0xb1
+	// LPS22HB and LPS33HW answer the same WHO_AM_I and share the register map,
+	// the ODRs and the scaling (4096 LSB/hPa, 100 LSB/degC), so both are handled as LPS22H.
+	chipLPS22H = 0xb1
+	// LPS22HH keeps the LPS22H register map for CTRL_REG1, the outputs and the scaling,
+	// so it is handled as LPS22H except for the features it moved (see Features).
+	chipLPS22HH = 0xb3
 )
 
 // NewI2C returns a Dev object that communicates over I2C.
 func NewI2C(b i2c.Bus, addr uint16, opts *Opts) (*Dev, error) {
+	return NewI2CContext(context.Background(), b, addr, opts)
+}
+
+// NewI2CContext is NewI2C with ctx for the bus transactions of the detection and Init.
+// It fails with ctx.Err() when ctx is done before a transaction.
+func NewI2CContext(ctx context.Context, b i2c.Bus, addr uint16, opts *Opts) (*Dev, error) {
+	if err := checkI2CAddr(addr); err != nil {
+		return nil, err
+	}
+	d := newI2CDev(b, addr)
+	if err := d.makeDev(ctx, opts); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// NewI2CAuto returns a Dev object on whichever of 0x5c and 0x5d answers WHO_AM_I with a known
+// or registered chip.
+// 0x5c is preferred when both do. The error joins the failures of both addresses when neither does.
+func NewI2CAuto(b i2c.Bus, opts *Opts) (*Dev, error) {
+	var errs []error
+	for _, addr := range []uint16{0x5c, 0x5d} {
+		if _, err := probeI2C(b, addr); err != nil {
+			errs = append(errs, fmt.Errorf("0x%02x: %w", addr, err))
+			continue
+		}
+		return NewI2C(b, addr, opts)
+	}
+	return nil, fmt.Errorf("lps: no device found: %w", errors.Join(errs...))
+}
+
+// NewI2CGroup returns a Dev object for each of addrs on the bus, in the same order, all with opts.
+// A bus has room for two devices (0x5c and 0x5d); behind a multiplexer, call it once per port.
+// The devices that fail are left nil and the error joins an *AddrError for each of them.
+func NewI2CGroup(b i2c.Bus, addrs []uint16, opts *Opts) ([]*Dev, error) {
+	devs := make([]*Dev, len(addrs))
+	var errs []error
+	for i, addr := range addrs {
+		d, err := NewI2C(b, addr, opts)
+		if err != nil {
+			errs = append(errs, &AddrError{Addr: addr, Err: err})
+			continue
+		}
+		devs[i] = d
+	}
+	return devs, errors.Join(errs...)
+}
+
+// Probe reads WHO_AM_I at addr and returns the name and the ID of the chip, writing nothing
+// to the device, e.g. to scan a bus without changing the configuration of the sensors.
+// An unknown ID is reported with an *UnsupportedChipError carrying it; the error of a failed
+// read is an *AddrError.
+func Probe(b i2c.Bus, addr uint16) (name string, id byte, err error) {
+	if err := checkI2CAddr(addr); err != nil {
+		return "", 0, err
+	}
+	id, err = probeI2C(b, addr)
+	if err != nil {
+		return "", id, &AddrError{Addr: addr, Err: err}
+	}
+	name, _ = chipName(id)
+	return name, id, nil
+}
+
+// probeI2C reads WHO_AM_I at addr and checks it is a known chip.
+func probeI2C(b i2c.Bus, addr uint16) (byte, error) {
+	var chipType [1]byte
+	c := i2c.Dev{Bus: b, Addr: addr}
+	if err := c.Tx([]byte{0x0F}, chipType[:]); err != nil {
+		return 0, fmt.Errorf("failed to read WHO_AM_I(0x0f): %w", err)
+	}
+	if _, ok := chipName(chipType[0]); !ok {
+		return chipType[0], &UnsupportedChipError{ID: chipType[0]}
+	}
+	return chipType[0], nil
+}
+
+// chipNames are the names of the built-in chips by WHO_AM_I.
+var chipNames = map[byte]string{
+	chipLPS331A: "LPS331A",
+	chipLPS25H:  "LPS25H",
+	chipLPS22H:  "LPS22H",
+	chipLPS22HH: "LPS22HH",
+}
+
+// chipName returns the name of the built-in or registered chip answering id to WHO_AM_I.
+func chipName(id byte) (string, bool) {
+	if name, ok := chipNames[id]; ok {
+		return name, true
+	}
+	if p, ok := registeredChip(id); ok {
+		return p.Name, true
+	}
+	return "", false
+}
+
+// Reopen re-points the device at addr on the same I2C bus and detects the chip again.
+// The options given at the construction are reused, and the software offsets are reset to theirs.
+func (d *Dev) Reopen(addr uint16) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	c, ok := d.d.(*i2c.Dev)
+	if !ok {
+		return d.wrap(errors.New("Reopen: supported only on I2C"))
+	}
+	if err := checkI2CAddr(addr); err != nil {
+		return err
+	}
+	nd := newI2CDev(c.Bus, addr)
+	nd.clock = d.clock
+	opts := d.opts
+	if err := nd.makeDev(context.Background(), &opts); err != nil {
+		return err
+	}
+	d.adopt(nd)
+	return nil
+}
+
+// adopt takes over the connection and the detected state of nd, built by makeDev.
+// The fields are assigned one by one under mu, which d keeps, so that the readers holding mu
+// never see a half-copied Dev. The clock and the SPI scratch buffer are kept as well.
+func (d *Dev) adopt(nd *Dev) {
+	d.d = nd.d
+	d.isSPI = nd.isSPI
+	d.spi3Wire = nd.spi3Wire
+	d.name = nd.name
+	d.chipID = nd.chipID
+	d.chipType = nd.chipType
+	d.oneshotMode = nd.oneshotMode
+	d.msbIncrement = nd.msbIncrement
+	d.oneshotStatusPoll = nd.oneshotStatusPoll
+	d.regs = nd.regs
+	d.initCmd = nd.initCmd
+	d.oneshotCmd = nd.oneshotCmd
+	d.ctrl1Base = nd.ctrl1Base
+	d.ctrl2Base = nd.ctrl2Base
+	d.ctrl3 = nd.ctrl3
+	d.drdyEnabled = nd.drdyEnabled
+	d.intLatched = nd.intLatched
+	d.resConf = nd.resConf
+	d.ctrl1Shadow = nd.ctrl1Shadow
+	d.resConfShadow = nd.resConfShadow
+	d.oneshotInterval = nd.oneshotInterval
+	d.opts = nd.opts
+	d.period = nd.period
+	d.ready = nd.ready
+	d.initAt = nd.initAt
+	d.prematureWarned = nd.prematureWarned
+	d.senses = nd.senses
+	d.intPin = nd.intPin
+	d.pressureOffset = nd.pressureOffset
+	d.pressureZero = nd.pressureZero
+	d.temperatureOffset = nd.temperatureOffset
+	d.refP = nd.refP
+	d.label = nd.label
+	d.profile = nd.profile
+	d.logger = nd.logger
+}
+
+func checkI2CAddr(addr uint16) error {
 	switch addr {
 	case 0x5c, 0x5d:
+		return nil
 	default:
-		return nil, errors.New("lps: given address not supported by device")
+		return fmt.Errorf("%w: 0x%02x", ErrUnsupportedAddress, addr)
 	}
-	d := &Dev{d: &i2c.Dev{Bus: b, Addr: addr}, isSPI: false}
-	if err := d.makeDev(opts); err != nil {
-		return nil, err
+}
+
+func newI2CDev(b i2c.Bus, addr uint16) *Dev {
+	return &Dev{
+		mu:    &sync.Mutex{},
+		d:     &i2c.Dev{Bus: b, Addr: addr},
+		isSPI: false,
+		clock: realClock{},
+	}
+}
+
+func newSPIDev(c conn.Conn, threeWire bool) *Dev {
+	return &Dev{
+		mu:       &sync.Mutex{},
+		d:        c,
+		isSPI:    true,
+		spi3Wire: threeWire,
+		clock:    realClock{},
 	}
-	return d, nil
 }
 
 // NewSPI returns a Dev object that communicates over SPI Mode3.
 func NewSPI(p spi.Port, opts *Opts) (*Dev, error) {
-	// It works both in Mode0 and Mode3.
-	c, err := p.Connect(10*physic.MegaHertz, spi.Mode3, 8)
+	return NewSPIContext(context.Background(), p, opts)
+}
+
+// NewSPIContext is NewSPI with ctx for the bus transactions of the detection and Init.
+// It fails with ctx.Err() when ctx is done before a transaction.
+func NewSPIContext(ctx context.Context, p spi.Port, opts *Opts) (*Dev, error) {
+	cfg := DefaultSPIConfig()
+	if opts != nil && opts.SPI != nil {
+		cfg = *opts.SPI
+		if cfg.Frequency == 0 {
+			cfg.Frequency = DefaultSPIConfig().Frequency
+		}
+	}
+	mode := cfg.Mode
+	if cfg.ThreeWire {
+		mode |= spi.HalfDuplex
+	}
+	c, err := p.Connect(cfg.Frequency, mode, 8)
 	if err != nil {
 		return nil, fmt.Errorf("lps: %v", err)
 	}
-	d := &Dev{d: c, isSPI: true}
-	if err := d.makeDev(opts); err != nil {
+	d := newSPIDev(c, cfg.ThreeWire)
+	if err := d.makeDev(ctx, opts); err != nil {
 		return nil, err
 	}
 	return d, nil
 }
 
+// SPIConfig is the clock and mode of the SPI connection.
+type SPIConfig struct {
+	// Frequency is the SPI clock. Zero means the default 10MHz.
+	Frequency physic.Frequency
+	// Mode is the SPI mode. The devices work both in Mode0 and Mode3.
+	Mode spi.Mode
+	// ThreeWire uses the 3-wire (half-duplex) interface with SDI/SDO shared.
+	// The SIM bit of CTRL_REG1 is set and kept set on every write to it.
+	// SWReset clears SIM as well, so it is not supported with ThreeWire.
+	ThreeWire bool
+}
+
+// DefaultSPIConfig returns the default SPI connection: 10MHz in Mode3.
+func DefaultSPIConfig() SPIConfig {
+	return SPIConfig{Frequency: 10 * physic.MegaHertz, Mode: spi.Mode3}
+}
+
 // MeasurementMode is a mode that measures one time and sleep the device or measures continuously.
 type MeasurementMode int
 
@@ -58,9 +275,189 @@ const (
 	Continuous
 )
 
+// String satisfies the fmt.Stringer interface.
+func (m MeasurementMode) String() string {
+	switch m {
+	case OneShot:
+		return "OneShot"
+	case Continuous:
+		return "Continuous"
+	default:
+		return fmt.Sprintf("MeasurementMode(%d)", int(m))
+	}
+}
+
+// ParseMeasurementMode parses the name returned by MeasurementMode.String, ignoring case.
+func ParseMeasurementMode(s string) (MeasurementMode, error) {
+	for _, m := range []MeasurementMode{OneShot, Continuous} {
+		if strings.EqualFold(s, m.String()) {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("lps: unknown measurement mode %q", s)
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.
+func (m MeasurementMode) MarshalText() ([]byte, error) {
+	if m != OneShot && m != Continuous {
+		return nil, fmt.Errorf("lps: unknown measurement mode %d", int(m))
+	}
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface.
+func (m *MeasurementMode) UnmarshalText(b []byte) error {
+	v, err := ParseMeasurementMode(string(b))
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// ODR is the output data rate in continuous mode.
+type ODR int
+
+const (
+	// ODRDefault is the default rate of the chip: 12.5Hz on LPS331A/LPS25H and 10Hz on LPS22H.
+	ODRDefault ODR = iota
+	// ODR1Hz is 1Hz (all chips).
+	ODR1Hz
+	// ODR7Hz is 7Hz (LPS331A, LPS25H).
+	ODR7Hz
+	// ODR10Hz is 10Hz (LPS22H).
+	ODR10Hz
+	// ODR12_5Hz is 12.5Hz (LPS331A, LPS25H).
+	ODR12_5Hz
+	// ODR25Hz is 25Hz (all chips).
+	ODR25Hz
+	// ODR50Hz is 50Hz (LPS22H).
+	ODR50Hz
+	// ODR75Hz is 75Hz (LPS22H).
+	ODR75Hz
+)
+
+var odrHz = map[ODR]float64{
+	ODR1Hz:    1,
+	ODR7Hz:    7,
+	ODR10Hz:   10,
+	ODR12_5Hz: 12.5,
+	ODR25Hz:   25,
+	ODR50Hz:   50,
+	ODR75Hz:   75,
+}
+
+// String satisfies the fmt.Stringer interface.
+func (o ODR) String() string {
+	if o == ODRDefault {
+		return "default"
+	}
+	if hz, ok := odrHz[o]; ok {
+		return fmt.Sprintf("%gHz", hz)
+	}
+	return fmt.Sprintf("ODR(%d)", int(o))
+}
+
+// period returns the interval between conversions, or zero for ODRDefault.
+func (o ODR) period() time.Duration {
+	hz, ok := odrHz[o]
+	if !ok {
+		return 0
+	}
+	return time.Duration(float64(time.Second) / hz)
+}
+
+// odrBits maps ODR to ODR[2:0] of CTRL_REG1 per chip.
+var odrBits = map[byte]map[ODR]byte{
+	// ODR2 ODR1 ODR0 (Pressure/Temperature)
+	chipLPS331A: {ODR1Hz: 0b001, ODR7Hz: 0b101, ODR12_5Hz: 0b110, ODR25Hz: 0b111},
+	chipLPS25H:  {ODR1Hz: 0b001, ODR7Hz: 0b010, ODR12_5Hz: 0b011, ODR25Hz: 0b100},
+	chipLPS22H:  {ODR1Hz: 0b001, ODR10Hz: 0b010, ODR25Hz: 0b011, ODR50Hz: 0b100, ODR75Hz: 0b101},
+}
+
 // Opts is a struct to set the mode of the device.
 type Opts struct {
 	Mode MeasurementMode
+	// ODR is the output data rate in Continuous mode. ODRs a chip does not support are rejected.
+	ODR ODR
+	// Averaging is the number of internal samples averaged (RES_CONF).
+	// In Continuous mode RES_CONF is left untouched unless it is set.
+	Averaging Averaging
+	// OneShotStatusPoll detects the end of a one-shot measurement by polling
+	// P_DA/T_DA in STATUS_REG instead of waiting for the ONE_SHOT bit to clear.
+	// It works around LPS25H lots whose ONE_SHOT bit does not self-clear reliably.
+	// Only supported on LPS25H.
+	OneShotStatusPoll bool
+	// IntPin is the host pin wired to the INT (INT_DRDY) output of the device.
+	// WaitForData routes the data-ready signal to it. SenseContinuousOnInterrupt
+	// expects the device to signal data-ready on it already.
+	IntPin gpio.PinIn
+	// IntPinOpts is the polarity and the output type of the INT pin.
+	IntPinOpts InterruptPinOpts
+	// WaitDataReady makes Sense in Continuous mode poll STATUS_REG until both new temperature
+	// and pressure are available before reading them, so that the first reading after the
+	// construction is valid. Every Sense then waits for the next conversion.
+	// It gives up with ErrMeasurementTimeout after two conversion periods.
+	WaitDataReady bool
+	// OneShotKeepPowered keeps the device powered on between one-shot measurements and
+	// only sets ONE_SHOT, as long as CTRL_REG1 and RES_CONF are known to be unchanged.
+	// Otherwise every one-shot measurement starts from powering down the device.
+	OneShotKeepPowered bool
+	// SkipInit makes the constructors detect the chip without calling Init, so nothing is written
+	// to the device. Call Init before any measurement.
+	SkipInit bool
+	// ResetCheckEvery makes every n-th Sense read CTRL_REG1 back first, and fail with ErrDeviceReset
+	// when it does not hold the last value written, as after a brownout. Zero disables the check.
+	ResetCheckEvery int
+	// OneShotMaxPolls limits how many times the completion of a one-shot measurement is polled.
+	// The measurement fails with ErrMeasurementTimeout after that. Zero means no limit.
+	OneShotMaxPolls int
+	// OneShotPollInterval is the interval between the polls of a one-shot measurement.
+	// Zero means a quarter of the conversion time estimated from Averaging, but 5 msec at least.
+	OneShotPollInterval time.Duration
+	// OneShotTimeout limits the time to wait for a one-shot measurement.
+	// The measurement fails with ErrMeasurementTimeout after that. Zero means no limit.
+	OneShotTimeout time.Duration
+	// OnReading is called with the values at the end of every successful Sense.
+	// It runs synchronously on the caller's goroutine, so it must not block.
+	OnReading func(SensorValues)
+	// OnSample is called with the raw counts and the values of every sample read by Sense and SenseAveraged,
+	// before OnReading. It runs synchronously on the caller's goroutine, so it must not block.
+	OnSample func(raw RawSample, values SensorValues)
+	// Logger receives the debug logs of the device, with the bus, address and chip attributes added.
+	// nil means slog.Default.
+	Logger *slog.Logger
+	// DisableBDU turns off the Block Data Update of CTRL_REG1.
+	// With BDU (the default) the output registers are not updated until both bytes are read,
+	// so a reading never mixes two samples.
+	DisableBDU bool
+	// ResetWait is the time waited after setting and after clearing SWRESET of LPS331A,
+	// which does not report the completion. Zero means 5 msec.
+	ResetWait time.Duration
+	// Retries is how many times a failed bus transaction is retried. Zero means no retry.
+	Retries int
+	// RetryDelay is the delay before the first retry. It doubles on every further retry.
+	RetryDelay time.Duration
+	// LowPower enables the low-current mode (LC_EN of RES_CONF) of LPS22H.
+	// It roughly halves the supply current at the cost of a higher noise.
+	// Only supported on LPS22H.
+	LowPower bool
+	// LowPassFilter is the additional low-pass filter on the pressure of LPS22H in Continuous mode.
+	// Only supported on LPS22H.
+	LowPassFilter LowPassFilter
+	// FIFOMean is the number of samples (2, 4, 8, 16 or 32) of the FIFO Mean mode of LPS25H,
+	// which outputs the running average of the pressure. Zero disables it.
+	// Only supported on LPS25H in Continuous mode.
+	FIFOMean int
+	// PressureOffset is the initial software offset added to every pressure reading.
+	PressureOffset physic.Pressure
+	// TemperatureOffset is the initial software offset added to every temperature reading.
+	TemperatureOffset physic.Temperature
+	// SPI overrides the SPI connection made by NewSPI. nil means DefaultSPIConfig.
+	SPI *SPIConfig
+	// AddrIncrement overrides how multiple-byte reads are addressed, for clones
+	// not following the convention of the chip they identify as.
+	AddrIncrement AddrIncrement
 }
 
 // DefaultOpts returns the default options.
@@ -71,95 +468,430 @@ func DefaultOpts() *Opts {
 }
 
 // Dev is a handle to the LPS device.
+// It is safe for concurrent use; the methods accessing the device are serialized.
 type Dev struct {
-	d           conn.Conn
-	isSPI       bool
-	name        string
+	// mu serializes the bus transactions. It is a pointer to survive Reopen.
+	mu    *sync.Mutex
+	d     conn.Conn
+	isSPI bool
+	// spi3Wire is true on the 3-wire (half-duplex) SPI interface.
+	spi3Wire bool
+	name     string
+	// chipID is the WHO_AM_I value, and chipType the chip whose register map is used.
+	chipID      byte
 	chipType    byte
 	oneshotMode bool
-	regs        struct {
-		ctrl_reg1 byte
-		ctrl_reg2 byte
-		res_conf  byte
+	// msbIncrement requests the sub-address increment of multiple-byte reads with the MSB.
+	msbIncrement bool
+	// oneshotStatusPoll polls STATUS_REG for the one-shot completion.
+	oneshotStatusPoll bool
+	regs              struct {
+		ctrl_reg1  byte
+		ctrl_reg2  byte
+		res_conf   byte
+		status_reg byte
+		// press_out and temp_out are PRESS_OUT_XL and TEMP_OUT_L.
+		press_out byte
+		temp_out  byte
 	}
 	initCmd byte
+	// oneshotCmd is the CTRL_REG1 value to power on for a one-shot measurement.
+	oneshotCmd byte
+	// ctrl1Base is kept set on every CTRL_REG1 write (SIM on 3-wire SPI, DIFF_EN).
+	ctrl1Base byte
+	// ctrl2Base is kept set on every CTRL_REG2 write (IF_ADD_INC on LPS22H).
+	ctrl2Base byte
+	// ctrl3 is the last value written to CTRL_REG3.
+	ctrl3 byte
+	// drdyEnabled is true once the data-ready signal is routed to INT1.
+	drdyEnabled bool
+	// intLatched is true while the pressure threshold interrupt is latched (LIR).
+	intLatched bool
+	// resConf is the RES_CONF value to apply.
+	resConf byte
+	// ctrl1Shadow and resConfShadow are the last values written to CTRL_REG1 and RES_CONF.
+	ctrl1Shadow, resConfShadow regShadow
+	// oneshotInterval is the interval between the polls of a one-shot measurement.
+	oneshotInterval time.Duration
+	// opts is the options given at the construction.
+	opts Opts
+	// period is the interval between conversions in continuous mode.
+	period time.Duration
+	// ready is true once the first conversion after Init is seen by Ready.
+	ready bool
+	// initAt is when Init started Continuous mode, and prematureWarned is true once
+	// a Sense too early after it is logged.
+	initAt          time.Time
+	prematureWarned bool
+	// senses counts the measurements for Opts.ResetCheckEvery.
+	senses int
+	// intPin is the host pin wired to the INT output, or nil.
+	intPin gpio.PinIn
+	// pressureOffset is a software trim added to every pressure reading.
+	pressureOffset physic.Pressure
+	// pressureZero is subtracted from every pressure reading after Zero, or zero.
+	pressureZero physic.Pressure
+	// temperatureOffset is a software trim added to every temperature reading.
+	temperatureOffset physic.Temperature
+	// refP is the last pressure written to REF_P, or nil.
+	refP *physic.Pressure
+	// spiBuf is the scratch buffer of SPI reads, used under mu:
+	// the received bytes first, and the bytes to send after maxBurst+1.
+	spiBuf [2 * (maxBurst + 1)]byte
+	// label is String of the device, put on the values it reads.
+	label string
+	// profile is the profile of a chip registered by RegisterChip, nil for the built-in chips.
+	profile *ChipProfile
+	// clock is the source of the time and the timers.
+	clock clock
+	// logger carries the bus, address and chip attributes of this device.
+	logger *slog.Logger
+}
+
+var _ conn.Resource = (*Dev)(nil)
+
+// String satisfies the conn.Resource interface. e.g. "LPS331A{I2C:0x5c}" or "LPS22H{SPI}"
+func (d *Dev) String() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.label
+}
+
+// describe builds String of the device.
+func (d *Dev) describe() string {
+	if c, ok := d.d.(*i2c.Dev); ok {
+		return fmt.Sprintf("%s{I2C:0x%02x}", d.name, c.Addr)
+	}
+	return fmt.Sprintf("%s{SPI}", d.name)
 }
 
-func (d *Dev) makeDev(opts *Opts) error {
+// Address returns the I2C address of the device. ok is false on SPI.
+func (d *Dev) Address() (addr uint16, ok bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if c, ok := d.d.(*i2c.Dev); ok {
+		return c.Addr, true
+	}
+	return 0, false
+}
+
+// ChipName returns the name of the detected chip. e.g. "LPS331A"
+func (d *Dev) ChipName() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.name
+}
+
+// ChipID returns the WHO_AM_I value of the detected chip.
+func (d *Dev) ChipID() byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.chipID
+}
+
+// Verify reads WHO_AM_I again and checks that it still identifies the detected chip.
+// A bus failure or 0x00/0xff is reported with ErrNoResponse, and a different value with a *ChipMismatchError.
+func (d *Dev) Verify() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	b := [1]byte{}
+	if err := d.readWhoAmI(context.Background(), b[:]); err != nil {
+		return d.wrap(fmt.Errorf("Verify: %w", err))
+	}
+	if b[0] != d.chipID {
+		return d.wrap(fmt.Errorf("Verify: %w", &ChipMismatchError{Want: d.chipID, Got: b[0]}))
+	}
+	return nil
+}
+
+// readWhoAmI reads register 0x0F "Who am I?" into chipType.
+// It is read once more when the bus fails or returns 0x00/0xff, as a floating or stuck bus does.
+// A bus failure or 0x00/0xff on both reads is reported with ErrNoResponse; an unknown ID is left to the caller.
+func (d *Dev) readWhoAmI(ctx context.Context, chipType []byte) error {
+	var err error
+	for i := 0; i < 2; i++ {
+		err = d.readReg(ctx, 0x0F, chipType)
+		if err == nil && chipType[0] != 0x00 && chipType[0] != 0xff {
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			// Not a silent bus.
+			return fmt.Errorf("lps: failed to read WHO_AM_I(0x0f): %w", ctxErr)
+		}
+	}
+
+	from := "SPI"
+	if c, ok := d.d.(*i2c.Dev); ok {
+		from = fmt.Sprintf("0x%02x", c.Addr)
+	}
+	if err == nil {
+		return fmt.Errorf("%w from %s: WHO_AM_I(0x0f) reads 0x%02x", ErrNoResponse, from, chipType[0])
+	}
+	return fmt.Errorf("%w from %s: failed to read WHO_AM_I(0x0f): %w", ErrNoResponse, from, err)
+}
+
+// busLogger returns l, or slog.Default when l is nil, with the bus and address attributes.
+func (d *Dev) busLogger(l *slog.Logger) *slog.Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	if c, ok := d.d.(*i2c.Dev); ok {
+		return l.With("bus", "i2c", "addr", fmt.Sprintf("0x%02x", c.Addr))
+	}
+	return l.With("bus", "spi")
+}
+
+// chipFamily returns the chip whose register map id uses.
+func chipFamily(id byte) byte {
+	if id == chipLPS22HH {
+		return chipLPS22H
+	}
+	return id
+}
+
+func (d *Dev) makeDev(ctx context.Context, opts *Opts) error {
 
 	if opts == nil {
 		opts = DefaultOpts()
 	}
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	d.logger = d.busLogger(opts.Logger)
 
 	var chipType [1]byte
-	// Read register 0x0F "Who am I?"
-	if err := d.readReg(0x0F, chipType[:]); err != nil {
+	if d.spi3Wire {
+		if err := d.probe3Wire(ctx, chipType[:]); err != nil {
+			return err
+		}
+	} else if err := d.readWhoAmI(ctx, chipType[:]); err != nil {
 		return err
 	}
 
-	var CTRL_REG1, CTRL_REG2, RES_CONF, ODRs, PD byte
+	var CTRL_REG1, CTRL_REG2, RES_CONF byte
+	// STATUS_REG, PRESS_OUT_XL and TEMP_OUT_L are at the same addresses on the built-in chips.
+	var STATUS_REG, PRESS_OUT, TEMP_OUT byte = 0x27, 0x28, 0x2b
 
 	switch chipType[0] {
 	case chipLPS331A:
-		d.name = "LPS331A"
+		d.name = chipNames[chipLPS331A]
 		RES_CONF = 0x10
 		CTRL_REG1 = 0x20
 		CTRL_REG2 = 0x21
-		ODRs = 0b110 // Data rate 12.5Hz
-		PD = 1
 	case chipLPS25H:
-		d.name = "LPS25H"
+		d.name = chipNames[chipLPS25H]
 		RES_CONF = 0x10
 		CTRL_REG1 = 0x20
 		CTRL_REG2 = 0x21
-		ODRs = 0b011 // Data rate 12.5Hz
-		PD = 1
-	case chipLPS22H:
-		d.name = "LPS22H"
+	case chipLPS22H, chipLPS22HH:
+		d.name = chipNames[chipType[0]]
 		RES_CONF = 0x00 // No RES_CONF
 		CTRL_REG1 = 0x10
 		CTRL_REG2 = 0x11
-		ODRs = 0b110 // Data rate 10Hz
-		PD = 0       // No PD Flag
+		// IF_ADD_INC[4] is 1 by default; keep it for multiple reads.
+		d.ctrl2Base = 0x10
 	default:
-		return fmt.Errorf("lps: unexpected chip Type %x", chipType[0])
+		p, ok := registeredChip(chipType[0])
+		if !ok {
+			return &UnsupportedChipError{ID: chipType[0]}
+		}
+		d.name = p.Name
+		RES_CONF = 0x00 // Not supported
+		CTRL_REG1 = p.CtrlReg1
+		CTRL_REG2 = p.CtrlReg2
+		if p.StatusReg != 0 {
+			STATUS_REG = p.StatusReg
+		}
+		if p.PressOut != 0 {
+			PRESS_OUT = p.PressOut
+		}
+		if p.TempOut != 0 {
+			TEMP_OUT = p.TempOut
+		}
+		d.profile = &p
 	}
 
-	slog.Debug("ChipType",
+	d.label = d.describe()
+	d.logger.Debug("ChipType",
 		"Value", fmt.Sprintf("0x%x", chipType[0]),
 		"Name", d.name)
-	d.chipType = chipType[0]
+	d.chipID = chipType[0]
+	d.chipType = chipFamily(chipType[0])
 
 	d.regs.ctrl_reg1 = CTRL_REG1
 	d.regs.ctrl_reg2 = CTRL_REG2
 	d.regs.res_conf = RES_CONF
-	d.initCmd = PD<<7 | ODRs<<4
+	d.regs.status_reg = STATUS_REG
+	d.regs.press_out = PRESS_OUT
+	d.regs.temp_out = TEMP_OUT
+	if d.spi3Wire {
+		// SIM[0]: 3-wire SPI interface
+		d.ctrl1Base = 1
+	}
+
+	if err := d.configure(opts); err != nil {
+		return err
+	}
+
+	if err := d.showCtrls(ctx); err != nil {
+		return err
+	}
+
+	if opts.SkipInit {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.init(ctx)
+}
+
+// chipDefaults returns the default ODR, PD[7] and the BDU bit of CTRL_REG1 of the detected chip.
+func (d *Dev) chipDefaults() (odr ODR, pd, bdu byte) {
+	switch d.chipType {
+	case chipLPS331A, chipLPS25H:
+		return ODR12_5Hz, 1, 1 << 2
+	case chipLPS22H:
+		return ODR10Hz, 0, 1 << 1 // No PD Flag
+	}
+	if d.profile != nil {
+		if d.profile.PowerDown {
+			pd = 1
+		}
+		return d.profile.DefaultODR, pd, d.profile.BDU
+	}
+	return ODRDefault, 0, 0
+}
 
-	slog.Debug("Cmds",
-		"CTRL_REG1", fmt.Sprintf("0x%02x", CTRL_REG1),
-		"CTRL_REG2", fmt.Sprintf("0x%02x", CTRL_REG2),
-		"RES_CONF", fmt.Sprintf("0x%02x", RES_CONF),
+// configure derives the command bytes and the driver settings from opts for the detected chip,
+// and makes opts the options of d. Nothing is written to the device.
+func (d *Dev) configure(opts *Opts) error {
+	odr, PD, BDU := d.chipDefaults()
+	if opts.ODR != ODRDefault {
+		odr = opts.ODR
+	}
+	chipODRs := odrBits[d.chipType]
+	if d.profile != nil {
+		chipODRs = d.profile.ODRBits
+	}
+	ODRs, ok := chipODRs[odr]
+	if !ok {
+		return d.wrap(fmt.Errorf("%w: ODR %s on %s", ErrUnsupportedOption, odr, d.name))
+	}
+
+	if err := d.checkFeatures(opts); err != nil {
+		return d.wrap(err)
+	}
+
+	if opts.IntPin != nil {
+		edge := gpio.RisingEdge
+		if opts.IntPinOpts.ActiveLow {
+			edge = gpio.FallingEdge
+		}
+		if err := opts.IntPin.In(gpio.PullNoChange, edge); err != nil {
+			return d.wrap(fmt.Errorf("failed to setup INT pin %s: %w", opts.IntPin, err))
+		}
+	}
+
+	d.opts = *opts
+	d.logger = d.busLogger(opts.Logger).With("chip", d.name)
+	d.period = odr.period()
+	d.oneshotStatusPoll = opts.OneShotStatusPoll
+	d.ctrl3 = opts.IntPinOpts.bits()
+	d.drdyEnabled = false
+	d.intPin = opts.IntPin
+	d.pressureOffset = opts.PressureOffset
+	d.temperatureOffset = opts.TemperatureOffset
+	d.msbIncrement = opts.AddrIncrement.msb(d.chipType)
+	if opts.DisableBDU {
+		BDU = 0
+	}
+	d.initCmd = PD<<7 | ODRs<<4 | opts.LowPassFilter.bits() | BDU | d.ctrl1Base
+	d.oneshotCmd = PD<<7 | BDU | d.ctrl1Base
+
+	d.logger.Debug("Cmds",
+		"CTRL_REG1", fmt.Sprintf("0x%02x", d.regs.ctrl_reg1),
+		"CTRL_REG2", fmt.Sprintf("0x%02x", d.regs.ctrl_reg2),
+		"RES_CONF", fmt.Sprintf("0x%02x", d.regs.res_conf),
 		"INIT_CMD", fmt.Sprintf("0b%08b(0x%02x)", d.initCmd, d.initCmd),
 		"PD", fmt.Sprintf("0b%b", PD),
 		"ODRs", fmt.Sprintf("0b%b", ODRs),
 	)
+	return nil
+}
+
+// Init initializes the device with opts, or with the current options when opts is nil.
+// opts replaces the options given at the construction as a whole, like passing them
+// to the constructor again without detecting the chip; the software offsets are reset to theirs.
+func (d *Dev) Init(opts *Opts) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 
-	if err := d.ShowCtrls(); err != nil {
+	if opts == nil {
+		current := d.opts
+		opts = &current
+	}
+	if err := opts.Validate(); err != nil {
+		return d.wrap(err)
+	}
+	if err := d.configure(opts); err != nil {
 		return err
 	}
-
-	return d.Init(opts)
+	return d.init(context.Background())
 }
 
-// Init initializes the device with options.
-func (d *Dev) Init(opts *Opts) error {
+// init writes the configuration derived by configure to the device. The caller holds mu.
+func (d *Dev) init(ctx context.Context) error {
+	opts := &d.opts
+
+	d.ready = false
+	if d.regs.res_conf != 0 {
+		cmd, err := d.resConfCmd(opts.Averaging)
+		if err != nil {
+			return d.wrap(err)
+		}
+		d.resConf = cmd
+	}
+	d.oneshotInterval = opts.OneShotPollInterval
+	if d.oneshotInterval <= 0 {
+		d.oneshotInterval = max(d.conversionTime(opts.Averaging)/4, defaultPollInterval)
+	}
+
+	if opts.LowPower {
+		if err := d.enableLowPower(ctx); err != nil {
+			return d.wrap(err)
+		}
+	}
+
+	if d.ctrl3 != 0 {
+		if r, ok := interruptRegMap[d.chipType]; ok {
+			if err := d.writeCommands(ctx, []byte{r.ctrlReg3, d.ctrl3}); err != nil {
+				return d.wrap(fmt.Errorf("failed to write CTRL_REG3(0x%x): %w", r.ctrlReg3, err))
+			}
+		}
+	}
 
-	if opts.Mode == OneShot {
-		d.oneshotMode = true
+	d.oneshotMode = opts.Mode == OneShot
+	if d.oneshotMode {
 		return nil
 	}
 
-	if err := d.writeCommands(
+	if !opts.Averaging.IsZero() {
+		if err := d.writeCommands(ctx,
+			[]byte{
+				d.regs.res_conf,
+				d.resConf,
+			}); err != nil {
+			return d.wrap(
+				fmt.Errorf("failed to write RES_CONF(0x%x): %w", d.regs.res_conf, err))
+		}
+	}
+
+	if err := d.writeCommands(ctx,
 		[]byte{
 			d.regs.ctrl_reg1,
 			d.initCmd,
@@ -167,55 +899,99 @@ func (d *Dev) Init(opts *Opts) error {
 		return d.wrap(
 			fmt.Errorf("failed to send init command: %w", err))
 	}
+	d.initAt = d.clock.Now()
+
+	if opts.FIFOMean != 0 {
+		if err := d.enableFIFOMean(ctx, opts.FIFOMean); err != nil {
+			return d.wrap(err)
+		}
+	}
+
+	if opts.LowPassFilter != LPFOff && d.features().LowPassFilter {
+		if err := d.resetLowPassFilter(ctx); err != nil {
+			return d.wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// Halt powers down the analog front end of the device by clearing CTRL_REG1.
+// It is safe to call in any mode and more than once.
+// Sense in OneShot mode powers the device up again; in Continuous mode call Init.
+func (d *Dev) Halt() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 
+	if err := d.writeCommands(context.Background(),
+		[]byte{
+			d.regs.ctrl_reg1,
+			d.ctrl1Base, // turn off
+		}); err != nil {
+		return d.wrap(
+			fmt.Errorf("Halt: failed to clear CTRL_REG1(0x%x): %w", d.regs.ctrl_reg1, err))
+	}
+	d.ready = false
 	return nil
 }
 
 // Boot is a function to send BOOT[7] command to the device.
+// It waits a fixed 10 msec after BOOT[7] is cleared; BootAndWait waits for the actual completion.
 func (d *Dev) Boot(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// BOOT reloads the registers.
+	d.invalidateShadows()
+
 	// set and check BOOT[7]
-	if err := d.setAndCheckCtrlReg2(ctx, 0b10000000); err != nil {
+	if err := d.setAndCheckCtrlReg2(ctx, 0b10000000, pollOpts{}); err != nil {
 		return d.wrap(err)
 	}
 
-	select {
-	case <-ctx.Done():
-		return d.wrap(ctx.Err())
-	case <-time.After(10 * time.Millisecond):
-		return nil
+	if err := waitCancel(ctx, d.clock.NewTimer(10*time.Millisecond)); err != nil {
+		return d.wrap(err)
 	}
+	return nil
 }
 
 // ShowCtrls is a function to show the control registers of the device.
 func (d *Dev) ShowCtrls() error {
+	return d.showCtrls(context.Background())
+}
+
+func (d *Dev) showCtrls(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
 	b := [1]byte{}
-	if err := d.readReg(d.regs.ctrl_reg1, b[:]); err != nil {
+	if err := d.readReg(ctx, d.regs.ctrl_reg1, b[:]); err != nil {
 		return d.wrap(
 			fmt.Errorf("ShowCtrls: failed to read CTRL_REG1(0x%x): %w", d.regs.ctrl_reg1, err))
 	}
 	reg1 := fmt.Sprintf("%08b(0x%02x)", b[0], b[0])
 	//fmt.Printf("CTRL_REG1: %08b(0x%02x)\n", b[0], b[0])
 
-	if err := d.readReg(d.regs.ctrl_reg2, b[:]); err != nil {
+	if err := d.readReg(ctx, d.regs.ctrl_reg2, b[:]); err != nil {
 		return fmt.Errorf("ShowCtrls: failed to read CTRL_REG2(0x%x): %w", d.regs.ctrl_reg2, err)
 	}
 	reg2 := fmt.Sprintf("%08b(0x%02x)", b[0], b[0])
 	//fmt.Printf("CTRL_REG2: %08b(0x%02x)\n", b[0], b[0])
 
 	if d.regs.res_conf == 0 {
-		slog.Debug("Ctrls", "", slog.GroupValue(
+		d.logger.Debug("Ctrls", "", slog.GroupValue(
 			slog.String(fmt.Sprintf("CTRL_REG1(0x%02x)", d.regs.ctrl_reg1), reg1),
 			slog.String(fmt.Sprintf("CTRL_REG2(0x%02x)", d.regs.ctrl_reg2), reg2),
 		))
 		return nil
 	}
 
-	if err := d.readReg(d.regs.res_conf, b[:]); err != nil {
+	if err := d.readReg(ctx, d.regs.res_conf, b[:]); err != nil {
 		return d.wrap(fmt.Errorf("ShowCtrls: failed to read RES_CONF(0x%x): %w", d.regs.res_conf, err))
 	}
 	resConf := fmt.Sprintf("%08b(0x%02x)", b[0], b[0])
 	//fmt.Printf("RES_CONF : %08b(0x%02x)\n", b[0], b[0])
-	slog.Debug("Ctrls", "", slog.GroupValue(
+	d.logger.Debug("Ctrls", "", slog.GroupValue(
 		slog.String(fmt.Sprintf("CTRL_REG1(0x%02x)", d.regs.ctrl_reg1), reg1),
 		slog.String(fmt.Sprintf("CTRL_REG2(0x%02x)", d.regs.ctrl_reg2), reg2),
 		slog.String(fmt.Sprintf("RES_CONF(0x%02x)", d.regs.res_conf), resConf),
@@ -0,0 +1,204 @@
+package lpsensors
+
+import (
+	"log/slog"
+	"time"
+
+	"periph.io/x/conn/v3/gpio"
+	"periph.io/x/conn/v3/i2c"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/spi"
+)
+
+// Option is a functional option to modify Opts.
+type Option func(*Opts)
+
+// WithMode sets Opts.Mode.
+func WithMode(m MeasurementMode) Option {
+	return func(o *Opts) {
+		o.Mode = m
+	}
+}
+
+// WithODR sets Opts.ODR.
+func WithODR(odr ODR) Option {
+	return func(o *Opts) {
+		o.ODR = odr
+	}
+}
+
+// WithAveraging sets Opts.Averaging.
+func WithAveraging(a Averaging) Option {
+	return func(o *Opts) {
+		o.Averaging = a
+	}
+}
+
+// WithOneShotStatusPoll sets Opts.OneShotStatusPoll.
+func WithOneShotStatusPoll(enable bool) Option {
+	return func(o *Opts) {
+		o.OneShotStatusPoll = enable
+	}
+}
+
+// WithIntPin sets Opts.IntPin.
+func WithIntPin(p gpio.PinIn) Option {
+	return func(o *Opts) {
+		o.IntPin = p
+	}
+}
+
+// WithIntPinOpts sets Opts.IntPinOpts.
+func WithIntPinOpts(o InterruptPinOpts) Option {
+	return func(opts *Opts) {
+		opts.IntPinOpts = o
+	}
+}
+
+// WithWaitDataReady sets Opts.WaitDataReady.
+func WithWaitDataReady(enable bool) Option {
+	return func(o *Opts) {
+		o.WaitDataReady = enable
+	}
+}
+
+// WithResetCheckEvery sets Opts.ResetCheckEvery.
+func WithResetCheckEvery(n int) Option {
+	return func(o *Opts) {
+		o.ResetCheckEvery = n
+	}
+}
+
+// WithSkipInit sets Opts.SkipInit.
+func WithSkipInit(skip bool) Option {
+	return func(o *Opts) {
+		o.SkipInit = skip
+	}
+}
+
+// WithOneShotKeepPowered sets Opts.OneShotKeepPowered.
+func WithOneShotKeepPowered(enable bool) Option {
+	return func(o *Opts) {
+		o.OneShotKeepPowered = enable
+	}
+}
+
+// WithOneShotMaxPolls sets Opts.OneShotMaxPolls.
+func WithOneShotMaxPolls(n int) Option {
+	return func(o *Opts) {
+		o.OneShotMaxPolls = n
+	}
+}
+
+// WithOneShotPoll sets Opts.OneShotPollInterval and Opts.OneShotTimeout.
+func WithOneShotPoll(interval, timeout time.Duration) Option {
+	return func(o *Opts) {
+		o.OneShotPollInterval = interval
+		o.OneShotTimeout = timeout
+	}
+}
+
+// WithResetWait sets Opts.ResetWait.
+func WithResetWait(wait time.Duration) Option {
+	return func(o *Opts) {
+		o.ResetWait = wait
+	}
+}
+
+// WithOnSample sets Opts.OnSample.
+func WithOnSample(f func(RawSample, SensorValues)) Option {
+	return func(o *Opts) {
+		o.OnSample = f
+	}
+}
+
+// WithLogger sets Opts.Logger.
+func WithLogger(l *slog.Logger) Option {
+	return func(o *Opts) {
+		o.Logger = l
+	}
+}
+
+// WithOnReading sets Opts.OnReading.
+func WithOnReading(f func(SensorValues)) Option {
+	return func(o *Opts) {
+		o.OnReading = f
+	}
+}
+
+// WithDisableBDU sets Opts.DisableBDU.
+func WithDisableBDU(disable bool) Option {
+	return func(o *Opts) {
+		o.DisableBDU = disable
+	}
+}
+
+// WithRetry sets Opts.Retries and Opts.RetryDelay.
+func WithRetry(retries int, delay time.Duration) Option {
+	return func(o *Opts) {
+		o.Retries = retries
+		o.RetryDelay = delay
+	}
+}
+
+// WithLowPower sets Opts.LowPower.
+func WithLowPower(enable bool) Option {
+	return func(o *Opts) {
+		o.LowPower = enable
+	}
+}
+
+// WithLowPassFilter sets Opts.LowPassFilter.
+func WithLowPassFilter(f LowPassFilter) Option {
+	return func(o *Opts) {
+		o.LowPassFilter = f
+	}
+}
+
+// WithFIFOMean sets Opts.FIFOMean.
+func WithFIFOMean(samples int) Option {
+	return func(o *Opts) {
+		o.FIFOMean = samples
+	}
+}
+
+// WithOffsets sets Opts.PressureOffset and Opts.TemperatureOffset.
+func WithOffsets(p physic.Pressure, t physic.Temperature) Option {
+	return func(o *Opts) {
+		o.PressureOffset = p
+		o.TemperatureOffset = t
+	}
+}
+
+// WithAddrIncrement sets Opts.AddrIncrement.
+func WithAddrIncrement(a AddrIncrement) Option {
+	return func(o *Opts) {
+		o.AddrIncrement = a
+	}
+}
+
+// WithSPIConfig sets Opts.SPI.
+func WithSPIConfig(c SPIConfig) Option {
+	return func(o *Opts) {
+		o.SPI = &c
+	}
+}
+
+// NewOpts returns DefaultOpts modified by the options.
+func NewOpts(options ...Option) *Opts {
+	o := DefaultOpts()
+	for _, opt := range options {
+		opt(o)
+	}
+	return o
+}
+
+// NewI2CWithOptions returns a Dev object that communicates over I2C, configured by functional options.
+func NewI2CWithOptions(b i2c.Bus, addr uint16, options ...Option) (*Dev, error) {
+	return NewI2C(b, addr, NewOpts(options...))
+}
+
+// NewSPIWithOptions returns a Dev object that communicates over SPI, configured by functional options.
+func NewSPIWithOptions(p spi.Port, options ...Option) (*Dev, error) {
+	return NewSPI(p, NewOpts(options...))
+}
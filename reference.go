@@ -0,0 +1,85 @@
+package lpsensors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"periph.io/x/conn/v3/physic"
+)
+
+// SetReferencePressure writes p to the REF_P registers (REF_P_XL, REF_P_L, REF_P_H).
+// The chip uses it for AUTOZERO/differential output and threshold interrupts.
+func (d *Dev) SetReferencePressure(p physic.Pressure) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := d.writeReferencePressure(p); err != nil {
+		return d.wrap(fmt.Errorf("SetReferencePressure: %w", err))
+	}
+	return nil
+}
+
+// ReferencePressure reads the REF_P registers.
+func (d *Dev) ReferencePressure() (physic.Pressure, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	reg, err := d.refPAddr()
+	if err != nil {
+		return 0, d.wrap(fmt.Errorf("ReferencePressure: %w", err))
+	}
+
+	datum := [3]byte{}
+	for i := byte(0); i < 3; i++ {
+		if err := d.readReg(context.Background(), reg+i, datum[i:i+1]); err != nil {
+			return 0, d.wrap(fmt.Errorf("ReferencePressure: failed to read REF_P(0x%x): %w", reg+i, err))
+		}
+	}
+	return DecodePressure(datum[0], datum[1], datum[2]), nil
+}
+
+// refPAddr returns the address of REF_P_XL. REF_P_L and REF_P_H follow it.
+func (d *Dev) refPAddr() (byte, error) {
+	switch d.chipType {
+	case chipLPS331A, chipLPS25H:
+		return 0x08, nil
+	case chipLPS22H:
+		if d.chipID == chipLPS22HH {
+			// REF_P is 16 bits on LPS22HH.
+			return 0, fmt.Errorf("%w on %s: REF_P", ErrUnsupportedOption, d.name)
+		}
+		return 0x15, nil
+	default:
+		return 0, fmt.Errorf("unknown chip type: %v", d.chipType)
+	}
+}
+
+func (d *Dev) writeReferencePressure(p physic.Pressure) error {
+	if p < 0 {
+		return errors.New("reference pressure must not be negative")
+	}
+	reg, err := d.refPAddr()
+	if err != nil {
+		return err
+	}
+
+	// 4096(PressureCountsPerHPa) [count/hPa]
+	raw := int64(p) * PressureCountsPerHPa / int64(100*physic.Pascal)
+	if raw > 0xffffff {
+		return fmt.Errorf("reference pressure %s out of range", p)
+	}
+
+	for i := byte(0); i < 3; i++ {
+		v := byte(raw >> (8 * i))
+		if err := d.writeCommands(context.Background(),
+			[]byte{
+				reg + i,
+				v,
+			}); err != nil {
+			return fmt.Errorf("failed to write REF_P(0x%x): %w", reg+i, err)
+		}
+	}
+	d.refP = &p
+	return nil
+}
@@ -0,0 +1,81 @@
+package lpsensors
+
+import (
+	"context"
+	"fmt"
+)
+
+// ReadRegister reads n bytes starting from the register reg.
+// The sub-address auto-increment of the chip (Opts.AddrIncrement) is applied when n > 1.
+//
+// This is an advanced API for debugging; it bypasses the state the driver keeps.
+func (d *Dev) ReadRegister(reg byte, n int) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if reg > 0x7f {
+		return nil, d.wrap(fmt.Errorf("ReadRegister: invalid register address 0x%02x", reg))
+	}
+	if n < 1 {
+		return nil, d.wrap(fmt.Errorf("ReadRegister: invalid length %d", n))
+	}
+
+	b := make([]byte, n)
+	if err := d.readReg(context.Background(), reg, b); err != nil {
+		return nil, d.wrap(fmt.Errorf("ReadRegister: failed to read 0x%02x: %w", reg, err))
+	}
+	return b, nil
+}
+
+// WriteRegister writes val to the register reg.
+//
+// This is an advanced API for debugging; it bypasses the state the driver keeps,
+// so a later Init or Sense may overwrite the register or misbehave with it.
+func (d *Dev) WriteRegister(reg, val byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if reg > 0x7f {
+		return d.wrap(fmt.Errorf("WriteRegister: invalid register address 0x%02x", reg))
+	}
+	if err := d.writeCommands(context.Background(), []byte{reg, val}); err != nil {
+		return d.wrap(fmt.Errorf("WriteRegister: failed to write 0x%02x: %w", reg, err))
+	}
+	return nil
+}
+
+// dumpAddrs are the registers read by DumpRegisters keyed by WHO_AM_I, in the order they are read.
+// STATUS_REG is read before the output registers, since reading them clears it.
+var dumpAddrs = map[byte][]byte{
+	// REF_P_XL..H, WHO_AM_I, RES_CONF, CTRL_REG1..3, STATUS_REG, PRESS_OUT_XL..H, TEMP_OUT_L..H
+	chipLPS331A: {0x08, 0x09, 0x0a, 0x0f, 0x10, 0x20, 0x21, 0x22, 0x27, 0x28, 0x29, 0x2a, 0x2b, 0x2c},
+	// REF_P_XL..H, WHO_AM_I, RES_CONF, CTRL_REG1..4, STATUS_REG, PRESS_OUT_XL..H, TEMP_OUT_L..H
+	chipLPS25H: {0x08, 0x09, 0x0a, 0x0f, 0x10, 0x20, 0x21, 0x22, 0x23, 0x27, 0x28, 0x29, 0x2a, 0x2b, 0x2c},
+	// WHO_AM_I, CTRL_REG1..3, REF_P_XL..H, RES_CONF, STATUS, PRESS_OUT_XL..H, TEMP_OUT_L..H
+	chipLPS22H: {0x0f, 0x10, 0x11, 0x12, 0x15, 0x16, 0x17, 0x1a, 0x27, 0x28, 0x29, 0x2a, 0x2b, 0x2c},
+	// WHO_AM_I, CTRL_REG1..3, REF_P_L..H, STATUS, PRESS_OUT_XL..H, TEMP_OUT_L..H; no RES_CONF
+	chipLPS22HH: {0x0f, 0x10, 0x11, 0x12, 0x15, 0x16, 0x27, 0x28, 0x29, 0x2a, 0x2b, 0x2c},
+}
+
+// DumpRegisters reads the identification, control, status, output and REF_P registers
+// of the detected chip for diagnostics, keyed by the register address.
+// Reading the output registers clears the data-available bits of STATUS_REG.
+func (d *Dev) DumpRegisters() (map[byte]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	addrs, ok := dumpAddrs[d.chipID]
+	if !ok {
+		return nil, d.wrap(fmt.Errorf("DumpRegisters: unknown chip ID: %x", d.chipID))
+	}
+
+	regs := make(map[byte]byte, len(addrs))
+	b := [1]byte{}
+	for _, reg := range addrs {
+		if err := d.readReg(context.Background(), reg, b[:]); err != nil {
+			return nil, d.wrap(fmt.Errorf("DumpRegisters: failed to read 0x%02x: %w", reg, err))
+		}
+		regs[reg] = b[0]
+	}
+	return regs, nil
+}
@@ -4,17 +4,86 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"periph.io/x/conn/v3/i2c"
 )
 
 // SWReset is a function to send SWRESET[2] command to the device.
+// LPS25H/LPS22H clear SWRESET[2] when done, which is polled. LPS331A does not,
+// so Opts.ResetWait is waited after setting and again after clearing it.
 func (d *Dev) SWReset(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.swReset(ctx)
+}
+
+// SWResetAndWait resets the device like SWReset, then polls until a register reads back
+// its reset value: RES_CONF on LPS331A/LPS25H and CTRL_REG2 on LPS22H.
+func (d *Dev) SWResetAndWait(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := d.swReset(ctx); err != nil {
+		return err
+	}
+	if err := d.waitResetValue(ctx); err != nil {
+		return d.wrap(fmt.Errorf("SWResetAndWait: %w", err))
+	}
+	return nil
+}
+
+// ResetAndReinit resets the device like SWReset, then initializes it again
+// with opts, or with the current options when opts is nil.
+// All the state kept by the driver is dropped like Reopen, including the register shadows,
+// the reference pressure and the data-ready routing; the chip is detected again.
+func (d *Dev) ResetAndReinit(ctx context.Context, opts *Opts) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if opts == nil {
+		current := d.opts
+		opts = &current
+	}
+	if err := opts.Validate(); err != nil {
+		return d.wrap(err)
+	}
+
+	if err := d.swReset(ctx); err != nil {
+		return err
+	}
+
+	var nd *Dev
+	if c, ok := d.d.(*i2c.Dev); ok {
+		nd = newI2CDev(c.Bus, c.Addr)
+	} else {
+		nd = newSPIDev(d.d, d.spi3Wire)
+	}
+	nd.clock = d.clock
+	if err := nd.makeDev(ctx, opts); err != nil {
+		return err
+	}
+	d.adopt(nd)
+	return nil
+}
+
+func (d *Dev) swReset(ctx context.Context) error {
+	if d.spi3Wire {
+		return d.wrap(fmt.Errorf("SWReset: %w: 3-wire SPI", ErrUnsupportedOption))
+	}
+
+	// SWRESET resets the registers to their default values.
+	d.invalidateShadows()
 
 	switch d.chipType {
 	case chipLPS331A:
-		return d.swResetLPS331(ctx)
+		if err := d.swResetLPS331(ctx); err != nil {
+			return d.wrap(err)
+		}
+		return nil
 	case chipLPS22H, chipLPS25H:
 		// set and check SWReset[2]
-		if err := d.setAndCheckCtrlReg2(ctx, 0b100); err != nil {
+		if err := d.setAndCheckCtrlReg2(ctx, 0b100, pollOpts{}); err != nil {
 			return d.wrap(fmt.Errorf("SWReset: failed :%w", err))
 		}
 		return nil
@@ -29,7 +98,7 @@ func (d *Dev) swResetLPS331(ctx context.Context) error {
 	const reset = byte(0b100)
 
 	// set SWRESET flag and just a wait
-	if err := d.writeCommands(
+	if err := d.writeCommands(ctx,
 		[]byte{
 			d.regs.ctrl_reg2,
 			reset,
@@ -38,13 +107,13 @@ func (d *Dev) swResetLPS331(ctx context.Context) error {
 	}
 
 	// wait for process SWRESET
-	timer := time.NewTimer(5 * time.Millisecond)
+	timer := d.clock.NewTimer(d.resetWait())
 	if err := waitCancel(ctx, timer); err != nil {
 		return fmt.Errorf("swResetLPS331: failed to wait process SWRESET: %w", err)
 	}
 
 	// clear CTRL_REG2 (NOT automatically cleared after SWRESET)
-	if err := d.writeCommands(
+	if err := d.writeCommands(ctx,
 		[]byte{
 			d.regs.ctrl_reg2,
 			0,
@@ -53,17 +122,79 @@ func (d *Dev) swResetLPS331(ctx context.Context) error {
 	}
 
 	// wait for process...
-	timer.Reset(5 * time.Millisecond)
+	timer.Reset(d.resetWait())
 	if err := waitCancel(ctx, timer); err != nil {
 		return fmt.Errorf("swResetLPS331: failed to wait clearing SWRESET: %w", err)
 	}
 
 	//read PRESS_OUT and TEMP_OUT to clear STATUS_REG
 	b := [5]byte{}
-	if err := d.readReg(0x28|0x80, b[:5]); err != nil {
+	if err := d.readReg(ctx, d.regs.press_out, b[:5]); err != nil {
 		return fmt.Errorf("swResetLPS331: failed to discard STATUS_REG(read PRESS/TEMP_OUT): %w", err)
 	}
 
 	return nil
 
 }
+
+// checkReset reads CTRL_REG1 back and fails with ErrDeviceReset when it does not hold
+// the last value written. It passes when the value is not known.
+func (d *Dev) checkReset(ctx context.Context) error {
+	if !d.ctrl1Shadow.valid {
+		return nil
+	}
+	want := d.ctrl1Shadow.val
+
+	b := [1]byte{}
+	if err := d.readReg(ctx, d.regs.ctrl_reg1, b[:]); err != nil {
+		return fmt.Errorf("checkReset: failed to read CTRL_REG1(0x%x): %w", d.regs.ctrl_reg1, err)
+	}
+	if b[0] != want {
+		d.invalidateShadows()
+		return fmt.Errorf("%w: CTRL_REG1(0x%x) reads 0x%02x, not 0x%02x",
+			ErrDeviceReset, d.regs.ctrl_reg1, b[0], want)
+	}
+	return nil
+}
+
+// resetWait returns Opts.ResetWait, or 5 msec when it is not set.
+func (d *Dev) resetWait() time.Duration {
+	if d.opts.ResetWait > 0 {
+		return d.opts.ResetWait
+	}
+	return 5 * time.Millisecond
+}
+
+// waitResetValue polls until RES_CONF (LPS331A/LPS25H) or CTRL_REG2 (LPS22H) reads back its reset value.
+// It gives up with ErrMeasurementTimeout after 10 polls 1 msec apart.
+func (d *Dev) waitResetValue(ctx context.Context) error {
+	reg, want := d.regs.res_conf, resConfDefaults[d.chipType]
+	if d.chipType == chipLPS22H {
+		// IF_ADD_INC[4]
+		reg, want = d.regs.ctrl_reg2, 0x10
+	}
+	if reg == 0 {
+		return nil
+	}
+
+	const interval, maxPolls = time.Millisecond, 10
+	b := [1]byte{}
+	timer := d.clock.NewTimer(interval)
+	for polls := 1; ; polls++ {
+		if err := d.readReg(ctx, reg, b[:]); err != nil {
+			return fmt.Errorf("waitResetValue: failed read from 0x%x: %w", reg, err)
+		}
+		if b[0] == want {
+			return nil
+		}
+		if polls >= maxPolls {
+			return fmt.Errorf("waitResetValue: 0x%x reads 0x%02x, not 0x%02x after %d polls: %w",
+				reg, b[0], want, polls, ErrMeasurementTimeout)
+		}
+
+		timer.Reset(interval)
+		if err := waitCancel(ctx, timer); err != nil {
+			return fmt.Errorf("waitResetValue: %w", err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package lpsensors
+
+import (
+	"context"
+	"fmt"
+
+	"periph.io/x/conn/v3/physic"
+)
+
+// specRange is the operating range given in the datasheet of a chip.
+type specRange struct {
+	minPressure, maxPressure       physic.Pressure
+	minTemperature, maxTemperature physic.Temperature
+}
+
+var specRanges = map[byte]specRange{
+	// LPS331AP: 260 to 1260 mbar, -40 to +85 degC
+	chipLPS331A: {
+		minPressure: 260 * 100 * physic.Pascal, maxPressure: 1260 * 100 * physic.Pascal,
+		minTemperature: physic.ZeroCelsius - 40*physic.Celsius, maxTemperature: physic.ZeroCelsius + 85*physic.Celsius,
+	},
+	// LPS25H: 260 to 1260 hPa, -30 to +105 degC
+	chipLPS25H: {
+		minPressure: 260 * 100 * physic.Pascal, maxPressure: 1260 * 100 * physic.Pascal,
+		minTemperature: physic.ZeroCelsius - 30*physic.Celsius, maxTemperature: physic.ZeroCelsius + 105*physic.Celsius,
+	},
+	// LPS22HB: 260 to 1260 hPa, -40 to +85 degC
+	chipLPS22H: {
+		minPressure: 260 * 100 * physic.Pascal, maxPressure: 1260 * 100 * physic.Pascal,
+		minTemperature: physic.ZeroCelsius - 40*physic.Celsius, maxTemperature: physic.ZeroCelsius + 85*physic.Celsius,
+	},
+}
+
+// PressureRange returns the operating pressure range in the datasheet of the detected chip,
+// e.g. 260 to 1260 hPa. It is zero for a chip registered by RegisterChip.
+func (d *Dev) PressureRange() (min, max physic.Pressure) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	r := specRanges[d.chipType]
+	return r.minPressure, r.maxPressure
+}
+
+// TemperatureRange returns the operating temperature range in the datasheet of the detected chip,
+// e.g. -40 to +85 degC. It is zero for a chip registered by RegisterChip.
+func (d *Dev) TemperatureRange() (min, max physic.Temperature) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	r := specRanges[d.chipType]
+	return r.minTemperature, r.maxTemperature
+}
+
+// SelfTest performs a one-shot measurement and checks that the pressure and temperature
+// are within the operating range of the chip. The software offsets are not applied.
+// In Continuous mode, RES_CONF is read first and written back after the measurement, which
+// sets the one-shot averaging, and then the continuous measurement is started again.
+// CTRL_REG2 and the FIFO are kept, since ONE_SHOT is set over the configuration of CTRL_REG2.
+// It returns an error wrapping ErrSelfTest when a value is out of range.
+func (d *Dev) SelfTest(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	r, ok := specRanges[d.chipType]
+	if !ok {
+		return d.wrap(fmt.Errorf("SelfTest: unknown chip type: %x", d.chipType))
+	}
+
+	var resConf [1]byte
+	saveResConf := !d.oneshotMode && d.regs.res_conf != 0
+	if saveResConf {
+		if err := d.readReg(ctx, d.regs.res_conf, resConf[:]); err != nil {
+			return d.wrap(fmt.Errorf("SelfTest: failed to read RES_CONF(0x%x): %w", d.regs.res_conf, err))
+		}
+	}
+
+	var e SensorValues
+	err := d.measureOneshot(ctx)
+	if err == nil {
+		err = d.sense(ctx, &e)
+	}
+
+	if !d.oneshotMode {
+		// Use a fresh context so that a canceled measurement still restores the configuration.
+		if rerr := d.restoreContinuous(context.Background(), saveResConf, resConf[0]); rerr != nil && err == nil {
+			err = rerr
+		}
+	}
+	if err != nil {
+		return d.wrap(fmt.Errorf("SelfTest: %w", err))
+	}
+
+	if p := e.Pressure - d.pressureOffset + d.pressureZero; p < r.minPressure || p > r.maxPressure {
+		return d.wrap(fmt.Errorf("%w: pressure %s out of %s..%s", ErrSelfTest, p, r.minPressure, r.maxPressure))
+	}
+	if t := e.Temperature - d.temperatureOffset; t < r.minTemperature || t > r.maxTemperature {
+		return d.wrap(fmt.Errorf("%w: temperature %s out of %s..%s", ErrSelfTest, t, r.minTemperature, r.maxTemperature))
+	}
+	return nil
+}
+
+// restoreContinuous writes RES_CONF back when restoreResConf, with the device powered down,
+// and starts the continuous measurement again after a one-shot measurement.
+func (d *Dev) restoreContinuous(ctx context.Context, restoreResConf bool, resConf byte) error {
+	if restoreResConf {
+		if err := d.writeCommands(ctx, []byte{d.regs.ctrl_reg1, d.ctrl1Base}); err != nil {
+			return fmt.Errorf("failed to clear CTRL_REG1(0x%x): %w", d.regs.ctrl_reg1, err)
+		}
+		if err := d.writeCommands(ctx, []byte{d.regs.res_conf, resConf}); err != nil {
+			return fmt.Errorf("failed to restore RES_CONF(0x%x): %w", d.regs.res_conf, err)
+		}
+	}
+	if err := d.writeCommands(ctx, []byte{d.regs.ctrl_reg1, d.initCmd}); err != nil {
+		return fmt.Errorf("failed to restart continuous mode: %w", err)
+	}
+	return nil
+}
@@ -2,120 +2,718 @@ package lpsensors
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"periph.io/x/conn/v3/physic"
 )
 
 // Sense reads the temperature and pressure from the device.
-func (d Dev) Sense(ctx context.Context, e *SensorValues) error {
+// Concurrent calls are serialized, so a one-shot measurement is never interleaved with another.
+func (d *Dev) Sense(ctx context.Context, e *SensorValues) error {
+	d.mu.Lock()
+	err := d.measureAndSense(ctx, e)
+	h := d.sampleHooks()
+	d.mu.Unlock()
+	if err != nil {
+		return wrapName(h.name, err)
+	}
+
+	h.report(*e)
+	return nil
+}
+
+// sampleHooks holds what the Sense methods use after releasing mu, copied while holding it.
+// Reopen and ResetAndReinit replace the options, so the callbacks are never read from d.opts unlocked.
+type sampleHooks struct {
+	name      string
+	onSample  func(RawSample, SensorValues)
+	onReading func(SensorValues)
+}
+
+// sampleHooks copies the callbacks and the chip name. The caller holds mu.
+func (d *Dev) sampleHooks() sampleHooks {
+	return sampleHooks{name: d.name, onSample: d.opts.OnSample, onReading: d.opts.OnReading}
+}
+
+// sample calls OnSample with the values of one sample.
+func (h sampleHooks) sample(v SensorValues) {
+	if h.onSample != nil {
+		h.onSample(v.Raw(), v)
+	}
+}
+
+// report calls OnSample and then OnReading with the values of a single-sample reading.
+func (h sampleHooks) report(v SensorValues) {
+	h.sample(v)
+	if h.onReading != nil {
+		h.onReading(v)
+	}
+}
 
-	if d.oneshotMode {
-		if err := d.measureOneshot(ctx); err != nil {
-			return d.wrap(err)
+// SenseAndSleep measures in OneShot mode like Sense, then powers the analog front end down
+// by clearing CTRL_REG1. Sense leaves it powered (PD=1) after the measurement, so the device
+// keeps drawing more than its power-down current until the next one. SenseAndSleep
+// brings it down to the power-down current in between, at the cost of writing CTRL_REG1
+// and RES_CONF again on every call, so it suits infrequent reads.
+// LPS22H has no PD bit and returns to power-down by itself after a one-shot measurement,
+// so nothing more is written there.
+//
+// The front end is powered down even when the measurement fails.
+// It returns ErrUnsupportedOption in Continuous mode.
+func (d *Dev) SenseAndSleep(ctx context.Context, e *SensorValues) error {
+	d.mu.Lock()
+	if !d.oneshotMode {
+		d.mu.Unlock()
+		return d.wrap(fmt.Errorf("SenseAndSleep: %w in Continuous mode", ErrUnsupportedOption))
+	}
+	err := d.measureAndSense(ctx, e)
+	if d.oneshotCmd&(1<<7) != 0 {
+		// PD[7] = 0; use a fresh context so that a canceled measurement still powers down.
+		if perr := d.writeCommands(context.Background(), []byte{d.regs.ctrl_reg1, d.ctrl1Base}); perr != nil && err == nil {
+			err = fmt.Errorf("SenseAndSleep: failed to clear CTRL_REG1(0x%x): %w", d.regs.ctrl_reg1, perr)
 		}
 	}
+	h := d.sampleHooks()
+	d.mu.Unlock()
+	if err != nil {
+		return wrapName(h.name, err)
+	}
 
-	if err := d.sense(e); err != nil {
-		return d.wrap(err)
+	h.report(*e)
+	return nil
+}
+
+// DataReady reads STATUS_REG and reports whether new temperature and pressure data are available.
+func (d *Dev) DataReady() (tempReady, pressReady bool, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	b := [1]byte{}
+	if err := d.readReg(context.Background(), d.regs.status_reg, b[:]); err != nil {
+		return false, false, d.wrap(fmt.Errorf("DataReady: failed to read STATUS_REG(0x%x): %w", d.regs.status_reg, err))
+	}
+	tDA, pDA := d.statusDA()
+	return b[0]&tDA != 0, b[0]&pDA != 0, nil
+}
+
+// Read reads the temperature and pressure like Sense and returns them.
+// Sense fills a caller-owned SensorValues instead, for repeated reads.
+func (d *Dev) Read(ctx context.Context) (SensorValues, error) {
+	var v SensorValues
+	err := d.Sense(ctx, &v)
+	return v, err
+}
+
+// SenseEnv reads the temperature and pressure into the periph physic.Env.
+// Humidity is left untouched since the device has no humidity element.
+// Dev does not implement physic.SenseEnv; Env returns an adapter which does.
+func (d *Dev) SenseEnv(e *physic.Env) error {
+	var v SensorValues
+	if err := d.Sense(context.Background(), &v); err != nil {
+		return err
+	}
+	e.Temperature = v.Temperature
+	e.Pressure = v.Pressure
+	return nil
+}
+
+// PressureCountsPerHPa is the resolution of PRESS_OUT on all supported chips.
+const PressureCountsPerHPa = 4096
+
+// RawSample is the output counts of a sample, before any conversion.
+type RawSample struct {
+	// Temperature is the TEMP_OUT count.
+	Temperature int16
+	// Pressure is the signed 24-bit PRESS_OUT count.
+	Pressure int32
+}
+
+// DetailedReading is an auditable record from the raw counts to the physical values.
+type DetailedReading struct {
+	// RawTemperature is the TEMP_OUT count.
+	RawTemperature int16
+	// RawPressure is the PRESS_OUT count.
+	RawPressure int32
+	// TemperatureScale is the conversion applied to RawTemperature.
+	TemperatureScale TemperatureScale
+	// PressureCountsPerHPa is the conversion applied to RawPressure.
+	PressureCountsPerHPa int64
+	// PressureOffset is the software offset added to the converted pressure.
+	PressureOffset physic.Pressure
+	// PressureZero is subtracted from the converted pressure after Zero.
+	PressureZero physic.Pressure
+	// TemperatureOffset is the software offset added to the converted temperature.
+	TemperatureOffset physic.Temperature
+	// Values are the final physical values.
+	Values SensorValues
+}
+
+// SenseDetailed reads the temperature and pressure with the raw counts and the constants applied.
+// The values are read like Sense, with the same checks, but the hooks are not called.
+func (d *Dev) SenseDetailed(ctx context.Context) (DetailedReading, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var r DetailedReading
+	if err := d.measureAndSense(ctx, &r.Values); err != nil {
+		return r, d.wrap(err)
+	}
+	r.RawTemperature = r.Values.RawTemperature
+	r.RawPressure = r.Values.RawPressure
+
+	r.TemperatureScale = d.chipTemperatureScale()
+	r.PressureCountsPerHPa = PressureCountsPerHPa
+	r.PressureOffset = d.pressureOffset
+	r.PressureZero = d.pressureZero
+	r.TemperatureOffset = d.temperatureOffset
+	return r, nil
+}
+
+// SetPressureOffset sets the software offset added to every pressure reading.
+// It is independent of the REF_P registers of the chip.
+func (d *Dev) SetPressureOffset(p physic.Pressure) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.pressureOffset = p
+}
+
+// SetTemperatureOffset sets the software offset added to every temperature reading.
+func (d *Dev) SetTemperatureOffset(t physic.Temperature) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.temperatureOffset = t
+}
+
+// Zero takes a reading and reports the pressure of the later readings relative to it,
+// e.g. to measure the differential pressure from the ambient. The relative pressure is
+// negative below the zero. It is done in software; REF_P of the chip is left untouched.
+// ClearZero returns to the absolute pressure.
+func (d *Dev) Zero(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	prev := d.pressureZero
+	d.pressureZero = 0
+	var e SensorValues
+	if err := d.measureAndSense(ctx, &e); err != nil {
+		d.pressureZero = prev
+		return d.wrap(fmt.Errorf("Zero: %w", err))
+	}
+	d.pressureZero = e.Pressure
+	d.logger.Debug("Zero", "Pressure", e.Pressure.String())
+	return nil
+}
+
+// ClearZero makes the readings report the absolute pressure again after Zero.
+func (d *Dev) ClearZero() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.pressureZero = 0
+}
+
+// UpdateOffsetFromReference takes a reading and adjusts the software pressure offset
+// so that the reading matches ref, an authoritative reference pressure.
+func (d *Dev) UpdateOffsetFromReference(ref physic.Pressure) error {
+	var e SensorValues
+	if err := d.Sense(context.Background(), &e); err != nil {
+		return err
 	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// ref is absolute; undo Zero.
+	d.pressureOffset += ref - (e.Pressure + d.pressureZero)
+	d.logger.Debug("UpdateOffsetFromReference",
+		"Reference", ref.String(),
+		"Reading", e.Pressure.String(),
+		"Offset", d.pressureOffset.String())
 	return nil
 }
 
-func (d Dev) measureOneshot(ctx context.Context) error {
+func (d *Dev) measureOneshot(ctx context.Context) error {
+
+	// The device stays powered on in single shot mode after a measurement;
+	// with OneShotKeepPowered, skip setting it up again unless the registers are changed since then.
+	if d.opts.OneShotKeepPowered &&
+		d.ctrl1Shadow.is(d.oneshotCmd) && (d.regs.res_conf == 0 || d.resConfShadow.is(d.resConf)) {
+		return d.startOneshot(ctx)
+	}
 
 	// Power down the device (clean start)
-	if err := d.writeCommands(
+	if err := d.writeCommands(ctx,
 		[]byte{
 			d.regs.ctrl_reg1,
-			0, // turn off
+			d.ctrl1Base, // turn off
 		}); err != nil {
 		return fmt.Errorf("measureOneshot: failed to clear CTRL_REG1(0x%x): %w",
 			d.regs.ctrl_reg1, err)
 	}
 
-	// Set the pressure sensor to higher-precision
+	// Set the pressure sensor to higher-precision (Opts.Averaging)
+	// LPS25H : 0b00001111 AVGT1 AVGT0 = 1 (Average 64) AVGP1 AVGP0 = 1 (Average 512)
+	// LPS331A: 0b01111010 AVGT2 AVGT1 AVGT0 = 1 (Average 128), AVGP3 AVGP1 = 1 (Average 512)
 	if d.regs.res_conf != 0 {
-		var cmd byte
-		switch d.chipType {
-		case chipLPS25H:
-			cmd = 0b00001111 // AVGT1 AVGT0 = 1 (Average 64) AVGP1 AVGP0 = 1 (Average 512)
-		case chipLPS331A:
-			cmd = 0b01111010 // AVGT2 AVGT1 AVGT0 AVGP3 = 1(Average 512) , AVGT2 AVGT1 AVGT1 = 0 1 0 (Average 4)
-		default:
-			return fmt.Errorf("measureOneshot: unknown chip type: %v", d.chipType)
-		}
+		cmd := d.resConf
 
-		if err := d.writeCommands(
+		if err := d.writeCommands(ctx,
 			[]byte{
 				d.regs.res_conf, // RES_CONF
 				cmd,
 			}); err != nil {
-			return fmt.Errorf("measureOneshot: failed to write cmd 0b%08b(0x%x) command CTRL_REG2(0x%x): %w",
-				cmd, cmd, d.regs.ctrl_reg2, err)
+			return fmt.Errorf("measureOneshot: failed to write cmd 0b%08b(0x%x) command RES_CONF(0x%x): %w",
+				cmd, cmd, d.regs.res_conf, err)
 		}
 
 	}
 
 	// Turn on the pressure sensor analog front end in single shot mode
-	if err := d.writeCommands(
+	if err := d.writeCommands(ctx,
 		[]byte{
 			d.regs.ctrl_reg1,
-			0b10000100, // PD=1 and BDU=1
+			d.oneshotCmd, // PD and BDU, ODR=0 (one-shot)
 		}); err != nil {
 		return fmt.Errorf("measureOneshot: failed to start ONE_SHOT command to CTRL_REG1(0x%x): %w",
 			d.regs.ctrl_reg1, err)
 	}
 
+	return d.startOneshot(ctx)
+}
+
+// startOneshot runs one shot measurement and waits until it is completed.
+func (d *Dev) startOneshot(ctx context.Context) error {
 	// Run one shot measurement (Temperature and Pressure), self clearing bit when done.
 	// Wait until the measurement is completed: Wait that reading
 
+	if d.oneshotStatusPoll {
+		// set ONE_SHOT[0] and wait for P_DA[1] and T_DA[0] of STATUS_REG
+		if err := d.writeCommands(ctx,
+			[]byte{
+				d.regs.ctrl_reg2,
+				d.ctrl2Base | 0b1,
+			}); err != nil {
+			return fmt.Errorf("measureOneshot: failed to set ONE_SHOT[0] to CTRL_REG2(0x%x): %w",
+				d.regs.ctrl_reg2, err)
+		}
+		if err := d.waitStatus(ctx, 0b11, d.oneshotPoll()); err != nil {
+			return fmt.Errorf("measureOneshot: failed to wait P_DA and T_DA: %w", err)
+		}
+		return nil
+	}
+
 	// set and check ONE_SHOT[0]
-	if err := d.setAndCheckCtrlReg2(ctx, 0b1); err != nil {
+	if err := d.setAndCheckCtrlReg2(ctx, 0b1, d.oneshotPoll()); err != nil {
 		return fmt.Errorf("measureOneshot: failed to set and check ONE_SHOT[0]: %w", err)
 	}
 	return nil
 }
 
-func (d Dev) sense(e *SensorValues) error {
+// oneshotPoll returns how the completion of a one-shot measurement is polled.
+func (d *Dev) oneshotPoll() pollOpts {
+	return pollOpts{
+		interval: d.oneshotInterval,
+		maxPolls: d.opts.OneShotMaxPolls,
+		timeout:  d.opts.OneShotTimeout,
+	}
+}
 
-	// In LPS22 with BDU feature, First read Temp. and then read Pressure.
-	// Document said that "To guarantee the correct behavior of BDU feature, PRESS_OUT_H (2Ah) must be the last address read."
+// SensePressure reads only the pressure from the device, with the checks of Sense.
+// In OneShot mode the device still measures both, but only PRESS_OUT is read.
+func (d *Dev) SensePressure(ctx context.Context) (physic.Pressure, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 
-	datum := [3]byte{}
+	if err := d.checkResetEvery(ctx); err != nil {
+		return 0, d.wrap(err)
+	}
+	if _, err := d.measure(ctx); err != nil {
+		return 0, d.wrap(err)
+	}
+
+	var p physic.Pressure
+	var raw int32
+	if err := d.sensePressure(ctx, &p, &raw); err != nil {
+		return 0, d.wrap(err)
+	}
+	return p, nil
+}
+
+// SenseTemperature reads only the temperature from the device, with the checks of Sense.
+// In OneShot mode the device still measures both, but only TEMP_OUT is read.
+// On LPS22H with BDU, PRESS_OUT is read as well and dropped, since the output registers
+// stay latched until PRESS_OUT_H is read.
+func (d *Dev) SenseTemperature(ctx context.Context) (physic.Temperature, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := d.checkResetEvery(ctx); err != nil {
+		return 0, d.wrap(err)
+	}
+	if _, err := d.measure(ctx); err != nil {
+		return 0, d.wrap(err)
+	}
+
+	var t physic.Temperature
+	var raw int16
+	if err := d.senseTemperature(ctx, &t, &raw); err != nil {
+		return 0, d.wrap(err)
+	}
+	if d.chipType == chipLPS22H && !d.opts.DisableBDU {
+		var p physic.Pressure
+		var rawP int32
+		if err := d.sensePressure(ctx, &p, &rawP); err != nil {
+			return 0, d.wrap(fmt.Errorf("SenseTemperature: failed to release BDU: %w", err))
+		}
+	}
+	return t, nil
+}
+
+// SenseAveraged takes n samples and stores their arithmetic mean in e.
+// The timestamp is the midpoint between the first and the last samples.
+// In Continuous mode the samples are spaced by the conversion period so that
+// the same output sample is not read twice. ctx is checked between samples.
+func (d *Dev) SenseAveraged(ctx context.Context, n int, e *SensorValues) error {
+	d.mu.Lock()
+	h := d.sampleHooks()
+	oneshot, period, label := d.oneshotMode, d.period, d.label
+	d.mu.Unlock()
+
+	if n < 1 {
+		return wrapName(h.name, fmt.Errorf("SenseAveraged: invalid sample count %d", n))
+	}
+
+	var sumT, sumP, sumRaw, sumRawP int64
+	var first, last time.Time
+	for i := 0; i < n; i++ {
+		if i > 0 && !oneshot {
+			if err := waitCancel(ctx, d.clock.NewTimer(period)); err != nil {
+				return wrapName(h.name, err)
+			}
+		} else if err := ctx.Err(); err != nil {
+			return wrapName(h.name, err)
+		}
+
+		var v SensorValues
+		d.mu.Lock()
+		err := d.measureAndSense(ctx, &v)
+		d.mu.Unlock()
+		if err != nil {
+			return wrapName(h.name, err)
+		}
+		h.sample(v)
+		sumT += int64(v.Temperature)
+		sumP += int64(v.Pressure)
+		sumRaw += int64(v.RawTemperature)
+		sumRawP += int64(v.RawPressure)
+		if i == 0 {
+			first = v.Timestamp
+		}
+		last = v.Timestamp
+	}
+
+	e.Temperature = physic.Temperature(sumT / int64(n))
+	e.Pressure = physic.Pressure(sumP / int64(n))
+	e.RawTemperature = int16(sumRaw / int64(n))
+	e.RawPressure = int32(sumRawP / int64(n))
+	e.Timestamp = first.Add(last.Sub(first) / 2)
+	e.DeviceName = label
+
+	if h.onReading != nil {
+		h.onReading(*e)
+	}
+	return nil
+}
+
+// SenseBestEffort reads the temperature and pressure from the device like Sense,
+// but keeps whatever channel was read successfully.
+// When a channel fails, e holds the valid values of the other channel and
+// the returned error wraps a *ChannelError naming the failed one.
+// It runs the Opts.ResetCheckEvery check like Sense, but the channels are read one by one
+// without STATUS_REG, so the overrun flags of e are left false, and the hooks are not called.
+func (d *Dev) SenseBestEffort(ctx context.Context, e *SensorValues) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := d.checkResetEvery(ctx); err != nil {
+		return d.wrap(err)
+	}
+	at, err := d.measure(ctx)
+	if err != nil {
+		return d.wrap(err)
+	}
+
+	var errs []error
+	if err := d.senseTemperature(ctx, &e.Temperature, &e.RawTemperature); err != nil {
+		errs = append(errs, &ChannelError{Channel: TemperatureChannel, Err: err})
+	}
+	if err := d.sensePressure(ctx, &e.Pressure, &e.RawPressure); err != nil {
+		errs = append(errs, &ChannelError{Channel: PressureChannel, Err: err})
+	}
+	e.Timestamp = d.stamp(at)
+	e.DeviceName = d.label
+	if len(errs) != 0 {
+		return d.wrap(errors.Join(errs...))
+	}
+	return nil
+}
+
+// measureAndSense runs a one-shot measurement when needed and reads the values.
+func (d *Dev) measureAndSense(ctx context.Context, e *SensorValues) error {
+	if err := d.checkResetEvery(ctx); err != nil {
+		return err
+	}
+
+	at, err := d.measure(ctx)
+	if err != nil {
+		return err
+	}
+	if err := d.sense(ctx, e); err != nil {
+		return err
+	}
+	e.Timestamp = d.stamp(at)
+	e.DeviceName = d.label
+	return nil
+}
+
+// checkResetEvery counts the reads and checks CTRL_REG1 on every Opts.ResetCheckEvery-th of them.
+func (d *Dev) checkResetEvery(ctx context.Context) error {
+	if n := d.opts.ResetCheckEvery; n > 0 {
+		d.senses++
+		if d.senses%n == 0 {
+			return d.checkReset(ctx)
+		}
+	}
+	return nil
+}
+
+// warnPremature logs once per device a read within one ODR period after Init in Continuous mode,
+// as the output registers may not hold a conversion yet. Ready or Opts.WaitDataReady avoid it.
+func (d *Dev) warnPremature() {
+	if d.prematureWarned || d.ready || d.initAt.IsZero() {
+		return
+	}
+	if since := d.clock.Now().Sub(d.initAt); since < d.period {
+		d.prematureWarned = true
+		d.logger.Warn("read within one ODR period after Init; the values may not be settled",
+			"Since", since.String(),
+			"Period", d.period.String())
+	}
+}
+
+// measure runs a one-shot measurement in OneShot mode and returns when it completed.
+// It returns the zero time in Continuous mode, after waiting for new data with Opts.WaitDataReady.
+func (d *Dev) measure(ctx context.Context) (time.Time, error) {
+	if !d.oneshotMode {
+		if d.opts.WaitDataReady {
+			tDA, pDA := d.statusDA()
+			p := pollOpts{interval: time.Millisecond, timeout: 2 * d.period}
+			if err := d.waitStatus(ctx, tDA|pDA, p); err != nil {
+				return time.Time{}, fmt.Errorf("measure: failed to wait P_DA and T_DA: %w", err)
+			}
+		} else {
+			d.warnPremature()
+		}
+		return time.Time{}, nil
+	}
+	if err := d.measureOneshot(ctx); err != nil {
+		return time.Time{}, err
+	}
+	return d.clock.Now(), nil
+}
+
+// stamp returns the timestamp of a sample measured at at, or read now in Continuous mode.
+func (d *Dev) stamp(at time.Time) time.Time {
+	if at.IsZero() {
+		return d.clock.Now()
+	}
+	return at
+}
+
+// decodeOverrun sets the overrun flags of e from STATUS_REG.
+func (d *Dev) decodeOverrun(e *SensorValues, status byte) {
+	tOR, pOR := d.statusOR()
+	e.TemperatureOverrun = status&tOR != 0
+	e.PressureOverrun = status&pOR != 0
+}
+
+// sense reads STATUS_REG, PRESS_OUT and TEMP_OUT into e.
+//
+// The read order releases the BDU latch of each chip:
+//   - LPS331A and LPS25H keep an output register pair until both bytes of it are read.
+//     Reading STATUS_REG through TEMP_OUT_H (0x27-0x2c) in a single transaction reads all of them.
+//   - LPS22H releases the latch when PRESS_OUT_H (0x2a) is read, so TEMP_OUT is read first,
+//     then PRESS_OUT, ending on 0x2a.
+func (d *Dev) sense(ctx context.Context, e *SensorValues) error {
+
+	if d.chipType == chipLPS22H {
+		if !d.oneshotMode {
+			// Overrun is only meaningful while the device keeps converting.
+			status := [1]byte{}
+			if err := d.readReg(ctx, d.regs.status_reg, status[:]); err != nil {
+				return fmt.Errorf("sense: failed to read STATUS_REG: %w", err)
+			}
+			d.decodeOverrun(e, status[0])
+		}
+		// In LPS22 with BDU feature, First read Temp. and then read Pressure.
+		// Document said that "To guarantee the correct behavior of BDU feature, PRESS_OUT_H (2Ah) must be the last address read."
+		// Reading the other way round would leave TEMP_OUT of the next sample latched.
+		if err := d.senseTemperature(ctx, &e.Temperature, &e.RawTemperature); err != nil {
+			return err
+		}
+		return d.sensePressure(ctx, &e.Pressure, &e.RawPressure)
+	}
+
+	if !d.outContiguous() {
+		status := [1]byte{}
+		if err := d.readReg(ctx, d.regs.status_reg, status[:]); err != nil {
+			return fmt.Errorf("sense: failed to read STATUS_REG: %w", err)
+		}
+		d.decodeOverrun(e, status[0])
+		if err := d.sensePressure(ctx, &e.Pressure, &e.RawPressure); err != nil {
+			return err
+		}
+		return d.senseTemperature(ctx, &e.Temperature, &e.RawTemperature)
+	}
+
+	datum := [6]byte{}
+
+	// Read 0x27(STATUS_REG) 0x28(PRESS_OUT_XL) 0x29(PRESS_OUT_L) 0x2a(PRESS_OUT_H)
+	// 0x2b(TEMP_OUT_L) 0x2c(TEMP_OUT_H) in a single transaction.
+	if err := d.readReg(ctx, d.regs.status_reg, datum[:]); err != nil {
+		return fmt.Errorf("sense: failed to read PRESS_OUT and TEMP_OUT: %w", err)
+	}
+	d.decodeOverrun(e, datum[0])
+	e.RawTemperature = rawTemperature(datum[4], datum[5])
+	if err := d.convertTemperature(&e.Temperature, e.RawTemperature); err != nil {
+		return fmt.Errorf("sense: %w", err)
+	}
+	e.RawPressure = rawPressure(datum[1], datum[2], datum[3])
+	e.Pressure = DecodePressure(datum[1], datum[2], datum[3]) + d.pressureOffset - d.pressureZero
+
+	return nil
+}
+
+// outContiguous reports whether STATUS_REG, PRESS_OUT and TEMP_OUT can be read in a single transaction.
+func (d *Dev) outContiguous() bool {
+	return d.regs.press_out == d.regs.status_reg+1 && d.regs.temp_out == d.regs.press_out+3
+}
+
+func (d *Dev) senseTemperature(ctx context.Context, t *physic.Temperature, raw *int16) error {
+
+	datum := [2]byte{}
 
 	// Read Temperature 0x2b(TEMP_OUT_L) 0x2c(TEMP_OUT_H)
-	if err := d.readReg(0x2b|0x80, datum[:2]); err != nil {
+	if err := d.readReg(ctx, d.regs.temp_out, datum[:2]); err != nil {
 		return fmt.Errorf("sense: failed to read TEMP_OUT: %w", err)
 	}
-	//rawTemp := int16(binary.LittleEndian.Uint16(b[3:]))
-	rawTemp := int16(datum[1])<<8 | int16(datum[0])
+	*raw = rawTemperature(datum[0], datum[1])
+	if err := d.convertTemperature(t, *raw); err != nil {
+		return fmt.Errorf("sense: %w", err)
+	}
+	return nil
+}
+
+// convertTemperature sets t from the raw TEMP_OUT count with the offset.
+func (d *Dev) convertTemperature(t *physic.Temperature, raw int16) error {
+	scale, err := d.temperatureScale()
+	if err != nil {
+		return err
+	}
+	*t = scale.Convert(raw) + d.temperatureOffset
+	return nil
+}
+
+// temperatureScale returns TemperatureScale, or ErrNoConversion when the conversion is unknown
+// so that a chip added without its scale does not report zeros.
+func (d *Dev) temperatureScale() (TemperatureScale, error) {
+	scale := d.chipTemperatureScale()
+	if scale.CountsPerCelsius == 0 {
+		return scale, fmt.Errorf("%w: no temperature scale for %s (0x%02x)", ErrNoConversion, d.name, d.chipID)
+	}
+	return scale, nil
+}
+
+// TemperatureScale is the conversion from the raw TEMP_OUT count to the temperature:
+// Offset + raw / CountsPerCelsius [degC].
+type TemperatureScale struct {
+	Offset           physic.Temperature
+	CountsPerCelsius int64
+}
 
+// Convert converts the raw TEMP_OUT count to the temperature.
+func (s TemperatureScale) Convert(raw int16) physic.Temperature {
+	return s.Offset + physic.Temperature(raw)*physic.Celsius/physic.Temperature(s.CountsPerCelsius)
+}
+
+// TemperatureScale returns the conversion used for the detected chip.
+// CountsPerCelsius is zero when the conversion is unknown.
+func (d *Dev) TemperatureScale() TemperatureScale {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.chipTemperatureScale()
+}
+
+func (d *Dev) chipTemperatureScale() TemperatureScale {
 	switch d.chipType {
 	case chipLPS331A:
 		// = 42.5 + (TEMP_OUT_H & TEMP_OUT_L) / 480
-		e.Temperature = physic.ZeroCelsius + 425*physic.Celsius/10 + physic.Temperature(rawTemp)*physic.Celsius/480
-	case chipLPS22H:
-	case chipLPS25H:
+		return TemperatureScale{Offset: physic.ZeroCelsius + 425*physic.Celsius/10, CountsPerCelsius: 480}
+	case chipLPS22H, chipLPS25H:
 		// 100 [count / degC]
-		e.Temperature = physic.ZeroCelsius + physic.Temperature(rawTemp)*physic.Celsius/100
+		return TemperatureScale{Offset: physic.ZeroCelsius, CountsPerCelsius: 100}
+	}
+	if d.profile != nil {
+		return d.profile.TemperatureScale
 	}
+	return TemperatureScale{}
+}
+
+func (d *Dev) sensePressure(ctx context.Context, p *physic.Pressure, raw *int32) error {
+
+	datum := [3]byte{}
 
 	// Read Pressure 0x28(PRESS_OUT_XL) 0x29(PRESS_OUT_L) 0x2a(PRESS_OUT_H)
-	// Read multiple bytes : 0b10000000 = 0x80
-	if err := d.readReg(0x28|0x80, datum[:3]); err != nil {
+	if err := d.readReg(ctx, d.regs.press_out, datum[:3]); err != nil {
 		return fmt.Errorf("sense: failed to read PRESS_OUT: %w", err)
 	}
 
-	//rawPress := uint64(binary.LittleEndian.Uint32(b[:]))
-	rawPress := int32(datum[2])<<16 | int32(datum[1])<<8 | int32(datum[0])
+	*raw = rawPressure(datum[0], datum[1], datum[2])
+	*p = DecodePressure(datum[0], datum[1], datum[2]) + d.pressureOffset - d.pressureZero
 
-	// rawPress / 4096 -> hPa (10^2 Pa)
+	return nil
+}
+
+// DecodePressure converts PRESS_OUT_XL, PRESS_OUT_L and PRESS_OUT_H to the pressure (PressureCountsPerHPa).
+// PRESS_OUT is a signed 24-bit value, so it is negative below REF_P when the reference is subtracted (AUTOZERO).
+func DecodePressure(xl, l, h byte) physic.Pressure {
+	rawPress := rawPressure(xl, l, h)
+
+	// rawPress / 4096(PressureCountsPerHPa) -> hPa (10^2 Pa)
 	// physic.Pressure = nanoPa (10^−9 Pa)
 
 	// h -> n 10^11: (10^11) / 4096 = (10^11) / 2048 / 2 = 48828125 / 2 = 24414062.5
 	const c = (1000 * 1000 * 1000 * 100) / 2048
-	e.Pressure = physic.Pressure(uint64(rawPress) * c / 2)
+	return physic.Pressure(int64(rawPress) * c / 2)
+}
 
-	return nil
+// DecodeTemperature converts TEMP_OUT_L and TEMP_OUT_H to the temperature with the scale.
+func DecodeTemperature(l, h byte, s TemperatureScale) physic.Temperature {
+	return s.Convert(rawTemperature(l, h))
+}
+
+func rawPressure(xl, l, h byte) int32 {
+	//rawPress := uint64(binary.LittleEndian.Uint32(b[:]))
+	// Shift PRESS_OUT_H into the top byte and back to sign-extend bit 23.
+	return int32(uint32(h)<<24|uint32(l)<<16|uint32(xl)<<8) >> 8
+}
+
+func rawTemperature(l, h byte) int16 {
+	//rawTemp := int16(binary.LittleEndian.Uint16(b[3:]))
+	// Assemble unsigned and reinterpret, so bit 7 of TEMP_OUT_H is the sign bit.
+	return int16(uint16(h)<<8 | uint16(l))
 }
@@ -1,8 +1,11 @@
 package lpsensors
 
 import (
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"math"
+	"time"
 
 	"periph.io/x/conn/v3/physic"
 )
@@ -11,6 +14,22 @@ import (
 type SensorValues struct {
 	Temperature physic.Temperature
 	Pressure    physic.Pressure
+	// RawTemperature is the TEMP_OUT count Temperature was converted from (see Dev.TemperatureScale).
+	RawTemperature int16
+	// RawPressure is the signed 24-bit PRESS_OUT count Pressure was converted from (see PressureCountsPerHPa).
+	RawPressure int32
+	// Timestamp is when the sample was taken: when a one-shot measurement completed,
+	// or when the output registers were read in Continuous mode.
+	// It is zero for the values not read by Sense and its variants, e.g. ReadFIFO.
+	Timestamp time.Time
+	// DeviceName is the device that read the values, e.g. "LPS331A{I2C:0x5c}" (see Dev.String).
+	// It is empty for the values not read by Sense and its variants.
+	DeviceName string
+	// PressureOverrun and TemperatureOverrun report P_OR and T_OR of STATUS_REG:
+	// a sample was overwritten before it was read, i.e. the device is read slower than its ODR.
+	// They are only meaningful in Continuous mode.
+	PressureOverrun    bool
+	TemperatureOverrun bool
 }
 
 // String satisfies the fmt.Stringer interface.
@@ -18,10 +37,165 @@ func (s SensorValues) String() string {
 	return fmt.Sprintf("Temperature: %s, Pressure: %s", s.Temperature, s.Pressure)
 }
 
+// TemperatureUnit is the unit of the temperature of SensorValues.Format.
+type TemperatureUnit int
+
+const (
+	// UnitCelsius formats the temperature in degrees Celsius, e.g. "25.3°C".
+	UnitCelsius TemperatureUnit = iota
+	// UnitFahrenheit formats the temperature in degrees Fahrenheit, e.g. "77.5°F".
+	UnitFahrenheit
+	// UnitKelvin formats the temperature in kelvins, e.g. "298.4 K".
+	UnitKelvin
+)
+
+// PressureUnit is the unit of the pressure of SensorValues.Format.
+type PressureUnit int
+
+const (
+	// UnitHectoPascal formats the pressure in hPa, e.g. "1013.2 hPa".
+	UnitHectoPascal PressureUnit = iota
+	// UnitKiloPascal formats the pressure in kPa, e.g. "101.3 kPa".
+	UnitKiloPascal
+	// UnitPascal formats the pressure in Pa, e.g. "101320.0 Pa".
+	UnitPascal
+)
+
+// Format returns the values in the units with one decimal, e.g. "25.3°C, 1013.2 hPa"
+// for UnitCelsius and UnitHectoPascal. Unknown units fall back to them.
+func (s SensorValues) Format(t TemperatureUnit, p PressureUnit) string {
+	var temp string
+	switch t {
+	case UnitFahrenheit:
+		temp = fmt.Sprintf("%.1f°F", s.Fahrenheit())
+	case UnitKelvin:
+		temp = fmt.Sprintf("%.1f K", float64(s.Temperature)/float64(physic.Kelvin))
+	default:
+		temp = fmt.Sprintf("%.1f°C", s.Celsius())
+	}
+
+	var press string
+	switch p {
+	case UnitKiloPascal:
+		press = fmt.Sprintf("%.1f kPa", s.Pascals()/1000)
+	case UnitPascal:
+		press = fmt.Sprintf("%.1f Pa", s.Pascals())
+	default:
+		press = fmt.Sprintf("%.1f hPa", s.HectoPascals())
+	}
+	return temp + ", " + press
+}
+
+// Raw returns the counts the values were converted from.
+func (s SensorValues) Raw() RawSample {
+	return RawSample{Temperature: s.RawTemperature, Pressure: s.RawPressure}
+}
+
+// Celsius returns the temperature in degrees Celsius.
+func (s SensorValues) Celsius() float64 {
+	return s.Temperature.Celsius()
+}
+
+// Fahrenheit returns the temperature in degrees Fahrenheit.
+func (s SensorValues) Fahrenheit() float64 {
+	return s.Temperature.Fahrenheit()
+}
+
+// Pascals returns the pressure in Pa.
+func (s SensorValues) Pascals() float64 {
+	return float64(s.Pressure) / float64(physic.Pascal)
+}
+
+// HectoPascals returns the pressure in hPa.
+func (s SensorValues) HectoPascals() float64 {
+	return float64(s.Pressure) / float64(100*physic.Pascal)
+}
+
 // LogValue satisfies the slog.Value interface.
+// The device is included unless DeviceName is empty.
 func (s SensorValues) LogValue() slog.Value {
+	if s.DeviceName == "" {
+		return slog.GroupValue(
+			slog.String("Temperature", s.Temperature.String()),
+			slog.String("Pressure", s.Pressure.String()),
+		)
+	}
 	return slog.GroupValue(
+		slog.String("device", s.DeviceName),
 		slog.String("Temperature", s.Temperature.String()),
 		slog.String("Pressure", s.Pressure.String()),
 	)
 }
+
+// sensorValuesJSON is the JSON form of SensorValues.
+type sensorValuesJSON struct {
+	TemperatureC   float64    `json:"temperature_c"`
+	PressureHPa    float64    `json:"pressure_hpa"`
+	RawTemperature int16      `json:"raw_temperature,omitempty"`
+	RawPressure    int32      `json:"raw_pressure,omitempty"`
+	Timestamp      *time.Time `json:"timestamp,omitempty"`
+	DeviceName     string     `json:"device,omitempty"`
+}
+
+// MarshalJSON satisfies the json.Marshaler interface.
+// e.g. {"temperature_c":25.3,"pressure_hpa":1013.2}
+// The timestamp is included in RFC 3339 unless it is zero.
+func (s SensorValues) MarshalJSON() ([]byte, error) {
+	v := sensorValuesJSON{
+		TemperatureC:   s.Celsius(),
+		PressureHPa:    s.HectoPascals(),
+		RawTemperature: s.RawTemperature,
+		RawPressure:    s.RawPressure,
+		DeviceName:     s.DeviceName,
+	}
+	if !s.Timestamp.IsZero() {
+		v.Timestamp = &s.Timestamp
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface.
+func (s *SensorValues) UnmarshalJSON(b []byte) error {
+	var v sensorValuesJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	s.Temperature = physic.ZeroCelsius + physic.Temperature(math.Round(v.TemperatureC*float64(physic.Celsius)))
+	s.Pressure = physic.Pressure(math.Round(v.PressureHPa * float64(100*physic.Pascal)))
+	s.RawTemperature = v.RawTemperature
+	s.RawPressure = v.RawPressure
+	s.DeviceName = v.DeviceName
+	s.Timestamp = time.Time{}
+	if v.Timestamp != nil {
+		s.Timestamp = *v.Timestamp
+	}
+	return nil
+}
+
+// Reading is a flat, primitive-typed form of SensorValues for serialization (e.g. protobuf).
+type Reading struct {
+	TemperatureMilliC int32
+	PressurePa        int32
+	UnixNanos         int64
+}
+
+// Reading converts the values into a Reading rounded to the nearest unit.
+// UnixNanos is Timestamp, or zero when Timestamp is zero, e.g. for the values read by ReadFIFO.
+func (s SensorValues) Reading() Reading {
+	r := Reading{
+		TemperatureMilliC: int32(roundDiv(int64(s.Temperature-physic.ZeroCelsius), int64(physic.MilliKelvin))),
+		PressurePa:        int32(roundDiv(int64(s.Pressure), int64(physic.Pascal))),
+	}
+	if !s.Timestamp.IsZero() {
+		r.UnixNanos = s.Timestamp.UnixNano()
+	}
+	return r
+}
+
+// roundDiv divides n by d rounding half away from zero.
+func roundDiv(n, d int64) int64 {
+	if n < 0 {
+		return (n - d/2) / d
+	}
+	return (n + d/2) / d
+}
@@ -0,0 +1,134 @@
+package lpsensors_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/walkure/go-lpsensors"
+	"periph.io/x/conn/v3/physic"
+)
+
+func Test_SensorValues_Reading(t *testing.T) {
+	var tc physic.Temperature
+	tc.Set("25.3C")
+
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+
+	r := lpsensors.SensorValues{Temperature: tc, Pressure: tp}.Reading()
+	assert.Equal(t, int32(25300), r.TemperatureMilliC)
+	assert.Equal(t, int32(101300), r.PressurePa)
+	// No Timestamp: the conversion does not stamp the values.
+	assert.Zero(t, r.UnixNanos)
+
+	tc.Set("-10.5C")
+	r = lpsensors.SensorValues{Temperature: tc}.Reading()
+	assert.Equal(t, int32(-10500), r.TemperatureMilliC)
+}
+
+func Test_Altitude(t *testing.T) {
+	assert.InDelta(t, 0, lpsensors.Altitude(lpsensors.StandardSeaLevel, lpsensors.StandardSeaLevel), 1e-9)
+
+	var p physic.Pressure
+	p.Set("89.875kPa")
+	assert.InDelta(t, 1000, lpsensors.Altitude(p, lpsensors.StandardSeaLevel), 1)
+
+	p.Set("105kPa")
+	assert.Less(t, lpsensors.SensorValues{Pressure: p}.Altitude(lpsensors.StandardSeaLevel), 0.0)
+
+	assert.True(t, math.IsNaN(lpsensors.Altitude(0, lpsensors.StandardSeaLevel)))
+}
+
+func Test_MeasurementMode(t *testing.T) {
+	assert.Equal(t, "OneShot", lpsensors.OneShot.String())
+	assert.Equal(t, "Continuous", lpsensors.Continuous.String())
+	assert.Equal(t, "MeasurementMode(5)", lpsensors.MeasurementMode(5).String())
+
+	m, err := lpsensors.ParseMeasurementMode("continuous")
+	assert.NoError(t, err)
+	assert.Equal(t, lpsensors.Continuous, m)
+
+	_, err = lpsensors.ParseMeasurementMode("1")
+	assert.Error(t, err)
+
+	var v struct{ Mode lpsensors.MeasurementMode }
+	assert.NoError(t, json.Unmarshal([]byte(`{"Mode":"OneShot"}`), &v))
+	assert.Equal(t, lpsensors.OneShot, v.Mode)
+	b, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"Mode":"OneShot"}`, string(b))
+
+	_, err = json.Marshal(struct{ Mode lpsensors.MeasurementMode }{5})
+	assert.Error(t, err)
+}
+
+func Test_SensorValues_JSON(t *testing.T) {
+	var tc physic.Temperature
+	tc.Set("25.3C")
+
+	var tp physic.Pressure
+	tp.Set("101.32kPa")
+
+	v := lpsensors.SensorValues{Temperature: tc, Pressure: tp}
+	b, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"temperature_c":25.3,"pressure_hpa":1013.2}`, string(b))
+
+	var got lpsensors.SensorValues
+	assert.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, v, got)
+
+	v.Timestamp = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	b, err = json.Marshal(v)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"temperature_c":25.3,"pressure_hpa":1013.2,"timestamp":"2024-05-01T12:00:00Z"}`, string(b))
+	assert.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, v, got)
+	assert.Equal(t, v.Timestamp.UnixNano(), v.Reading().UnixNanos)
+}
+
+func Test_SensorValues_Units(t *testing.T) {
+	var tc physic.Temperature
+	tc.Set("25C")
+
+	var tp physic.Pressure
+	tp.Set("101.325kPa")
+
+	v := lpsensors.SensorValues{Temperature: tc, Pressure: tp}
+	assert.InDelta(t, 25, v.Celsius(), 1e-9)
+	assert.InDelta(t, 77, v.Fahrenheit(), 1e-6)
+	assert.InDelta(t, 101325, v.Pascals(), 1e-9)
+	assert.InDelta(t, 1013.25, v.HectoPascals(), 1e-9)
+	assert.Zero(t, testing.AllocsPerRun(10, func() { _ = v.Fahrenheit() + v.HectoPascals() }))
+}
+
+func Test_SensorValues_Format(t *testing.T) {
+	var tc physic.Temperature
+	tc.Set("25.3C")
+
+	var tp physic.Pressure
+	tp.Set("101.32kPa")
+
+	v := lpsensors.SensorValues{Temperature: tc, Pressure: tp}
+	assert.Equal(t, "25.3°C, 1013.2 hPa", v.Format(lpsensors.UnitCelsius, lpsensors.UnitHectoPascal))
+	assert.Equal(t, "77.5°F, 101.3 kPa", v.Format(lpsensors.UnitFahrenheit, lpsensors.UnitKiloPascal))
+	assert.Equal(t, "298.4 K, 101320.0 Pa", v.Format(lpsensors.UnitKelvin, lpsensors.UnitPascal))
+	// The default String is unchanged.
+	assert.Equal(t, "Temperature: 25.300°C, Pressure: 101.320kPa", v.String())
+}
+
+func Test_SensorValues_LogValue(t *testing.T) {
+	var tc physic.Temperature
+	tc.Set("25C")
+
+	v := lpsensors.SensorValues{Temperature: tc}
+	assert.Len(t, v.LogValue().Group(), 2)
+
+	v.DeviceName = "LPS22H{I2C:0x5c}"
+	attrs := v.LogValue().Group()
+	assert.Equal(t, "device", attrs[0].Key)
+	assert.Equal(t, "LPS22H{I2C:0x5c}", attrs[0].Value.String())
+}
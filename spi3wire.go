@@ -0,0 +1,50 @@
+package lpsensors
+
+import (
+	"context"
+	"fmt"
+)
+
+// resConfDefaults are the RES_CONF(0x10) values after the power-on of LPS331A and LPS25H.
+var resConfDefaults = map[byte]byte{
+	chipLPS331A: 0x7a,
+	chipLPS25H:  0x05,
+}
+
+// probe3Wire sets SIM of CTRL_REG1 and reads WHO_AM_I into chipType over 3-wire SPI.
+// The device answers reads only after SIM is set, but CTRL_REG1 depends on the chip:
+// 0x10 of LPS22H is tried first as it is RES_CONF on LPS331A/LPS25H, and 0x20 of
+// LPS331A/LPS25H next. RES_CONF is restored to its power-on value afterwards.
+func (d *Dev) probe3Wire(ctx context.Context, chipType []byte) error {
+	// LPS22H
+	if err := d.setSIMAndReadID(ctx, 0x10, chipType); err != nil {
+		return err
+	}
+	if chipType[0] == chipLPS22H || chipType[0] == chipLPS22HH {
+		return nil
+	}
+
+	// LPS331A, LPS25H
+	if err := d.setSIMAndReadID(ctx, 0x20, chipType); err != nil {
+		return err
+	}
+	v, ok := resConfDefaults[chipType[0]]
+	if !ok {
+		return &UnsupportedChipError{ID: chipType[0]}
+	}
+	if err := d.writeCommands(ctx, []byte{0x10, v}); err != nil {
+		return fmt.Errorf("lps: failed to restore RES_CONF(0x10): %w", err)
+	}
+	return nil
+}
+
+func (d *Dev) setSIMAndReadID(ctx context.Context, ctrlReg1 byte, chipType []byte) error {
+	// SIM[0]
+	if err := d.writeCommands(ctx, []byte{ctrlReg1, 1}); err != nil {
+		return fmt.Errorf("lps: failed to set SIM of CTRL_REG1(0x%x): %w", ctrlReg1, err)
+	}
+	if err := d.readReg(ctx, 0x0F, chipType); err != nil {
+		return fmt.Errorf("lps: failed to read WHO_AM_I(0x0f): %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package lpsensors_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/walkure/go-lpsensors"
+	"periph.io/x/conn/v3/conntest"
+	"periph.io/x/conn/v3/physic"
+	"periph.io/x/conn/v3/spi"
+	"periph.io/x/conn/v3/spi/spitest"
+)
+
+func init_LPS331ASPIOps() []conntest.IO {
+	return []conntest.IO{
+		// Chip ID detection.
+		{W: []byte{0x0f | 0x80, 0x00}, R: []byte{0x00, 0xbb}},
+		// CTRL_REG1 show
+		{W: []byte{LPS331A_CTRL_REG1 | 0x80, 0x00}, R: []byte{0x00, 0xff}},
+		// CTRL_REG2 show
+		{W: []byte{LPS331A_CTRL_REG2 | 0x80, 0x00}, R: []byte{0x00, 0xff}},
+		// RES_CONF show
+		{W: []byte{LPS331A_RES_CONF | 0x80, 0x00}, R: []byte{0x00, 0xff}},
+	}
+}
+
+func Test_LPS331A_SPI_Write(t *testing.T) {
+	ops := append(init_LPS331ASPIOps(),
+		// CTRL_REG1 setup for continuous measurement; RW(MSB) is 0.
+		conntest.IO{W: []byte{LPS331A_CTRL_REG1, 0xe4}},
+		// CTRL_REG1 power down
+		conntest.IO{W: []byte{LPS331A_CTRL_REG1, 0x00}},
+	)
+
+	port := spitest.Playback{
+		Playback: conntest.Playback{Ops: ops},
+	}
+
+	d, err := lpsensors.NewSPI(&port, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.Equal(t, "LPS331A{SPI}", d.String())
+	_, ok := d.Address()
+	assert.False(t, ok)
+
+	if err := d.Halt(); err != nil {
+		t.Fatalf("halt err: %v", err)
+	}
+	assert.NoError(t, port.Close())
+}
+
+func Test_LPS331A_SPI_Continuous_Measurement(t *testing.T) {
+	ops := append(init_LPS331ASPIOps(),
+		// CTRL_REG1 setup for continuous measurement
+		conntest.IO{W: []byte{LPS331A_CTRL_REG1, 0xe4}},
+		// Read STATUS_REG, PRESS_OUT_XL, PRESS_OUT_L, PRESS_OUT_H, TEMP_OUT_L, TEMP_OUT_H;
+		// RW(bit 7) and MS(bit 6) set
+		// (0x3f5000=4149248) / 4096 = 1013 hPa
+		// (0x6bd0 = 27600) / 480 + 42.5 = 100 degC
+		conntest.IO{
+			W: []byte{0x27 | 0xc0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+			R: []byte{0x00, 0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b},
+		},
+	)
+
+	port := spitest.Playback{
+		Playback: conntest.Playback{Ops: ops},
+	}
+
+	d, err := lpsensors.NewSPI(&port, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+
+	var tc physic.Temperature
+	tc.Set("100C")
+
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+
+	assert.Equal(t, tc, data.Temperature)
+	assert.Equal(t, tp, data.Pressure)
+	assert.NoError(t, port.Close())
+}
+
+func Test_LPS331A_SPI_RawRegisters(t *testing.T) {
+	ops := append(init_LPS331ASPIOps(),
+		// CTRL_REG1 setup for continuous measurement
+		conntest.IO{W: []byte{LPS331A_CTRL_REG1, 0xe4}},
+		// REF_P_XL..REF_P_H; RW(bit 7) and MS(bit 6) set
+		conntest.IO{W: []byte{0x08 | 0xc0, 0x00, 0x00, 0x00}, R: []byte{0x00, 0x01, 0x02, 0x03}},
+		// WHO_AM_I; RW(bit 7) set
+		conntest.IO{W: []byte{0x0f | 0x80, 0x00}, R: []byte{0x00, 0xbb}},
+		// CTRL_REG3; RW(bit 7) cleared
+		conntest.IO{W: []byte{0x22, 0x04}},
+	)
+
+	port := spitest.Playback{
+		Playback: conntest.Playback{Ops: ops},
+	}
+
+	d, err := lpsensors.NewSPI(&port, nil)
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+
+	b, err := d.ReadRegister(0x08, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x01, 0x02, 0x03}, b)
+
+	b, err = d.ReadRegister(0x0f, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xbb}, b)
+
+	assert.NoError(t, d.WriteRegister(0x22, 0x04))
+
+	_, err = d.ReadRegister(0x80, 1)
+	assert.Error(t, err)
+	assert.NoError(t, port.Close())
+}
+
+// connectPort records the parameters of Connect.
+type connectPort struct {
+	spitest.Playback
+	f    physic.Frequency
+	mode spi.Mode
+}
+
+func (p *connectPort) Connect(f physic.Frequency, mode spi.Mode, bits int) (spi.Conn, error) {
+	p.f, p.mode = f, mode
+	return p.Playback.Connect(f, mode, bits)
+}
+
+func Test_LPS331A_SPIConfig(t *testing.T) {
+	ops := append(init_LPS331ASPIOps(),
+		// CTRL_REG1 setup for continuous measurement
+		conntest.IO{W: []byte{LPS331A_CTRL_REG1, 0xe4}},
+	)
+
+	port := connectPort{Playback: spitest.Playback{Playback: conntest.Playback{Ops: ops}}}
+	if _, err := lpsensors.NewSPI(&port, nil); err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.Equal(t, 10*physic.MegaHertz, port.f)
+	assert.Equal(t, spi.Mode3, port.mode)
+
+	port = connectPort{Playback: spitest.Playback{Playback: conntest.Playback{Ops: ops}}}
+	if _, err := lpsensors.NewSPIWithOptions(&port, lpsensors.WithSPIConfig(lpsensors.SPIConfig{
+		Frequency: physic.MegaHertz,
+		Mode:      spi.Mode0,
+	})); err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.Equal(t, physic.MegaHertz, port.f)
+	assert.Equal(t, spi.Mode0, port.mode)
+	assert.NoError(t, port.Close())
+}
+
+func Test_LPS331A_SPI_ThreeWire(t *testing.T) {
+	ops := []conntest.IO{
+		// SIM of LPS22H CTRL_REG1 (RES_CONF on LPS331A); WHO_AM_I is not answered yet.
+		{W: []byte{0x10, 0x01}},
+		{W: []byte{0x0f | 0x80}, R: []byte{0xff}},
+		// SIM of LPS331A CTRL_REG1
+		{W: []byte{LPS331A_CTRL_REG1, 0x01}},
+		{W: []byte{0x0f | 0x80}, R: []byte{0xbb}},
+		// RES_CONF restored
+		{W: []byte{LPS331A_RES_CONF, 0x7a}},
+		// CTRL_REG1, CTRL_REG2, RES_CONF show; the address, then the data.
+		{W: []byte{LPS331A_CTRL_REG1 | 0x80}, R: []byte{0x01}},
+		{W: []byte{LPS331A_CTRL_REG2 | 0x80}, R: []byte{0x00}},
+		{W: []byte{LPS331A_RES_CONF | 0x80}, R: []byte{0x7a}},
+		// CTRL_REG1 setup for continuous measurement, keeping SIM[0]
+		{W: []byte{LPS331A_CTRL_REG1, 0xe5}},
+		// Read STATUS_REG, pressure and temperature; RW(bit 7) and MS(bit 6) set
+		{W: []byte{0x27 | 0xc0}, R: []byte{0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b}},
+		// CTRL_REG1 power down, keeping SIM[0]
+		{W: []byte{LPS331A_CTRL_REG1, 0x01}},
+	}
+
+	port := connectPort{Playback: spitest.Playback{Playback: conntest.Playback{Ops: ops}}}
+	d, err := lpsensors.NewSPIWithOptions(&port, lpsensors.WithSPIConfig(lpsensors.SPIConfig{
+		Mode:      spi.Mode3,
+		ThreeWire: true,
+	}))
+	if err != nil {
+		t.Fatalf("lps err: %v", err)
+	}
+	assert.Equal(t, spi.Mode3|spi.HalfDuplex, port.mode)
+
+	data := lpsensors.SensorValues{}
+	if err := d.Sense(context.TODO(), &data); err != nil {
+		t.Fatalf("sense err: %v", err)
+	}
+
+	var tp physic.Pressure
+	tp.Set("101.3kPa")
+	assert.Equal(t, tp, data.Pressure)
+
+	assert.NoError(t, d.Halt())
+	assert.NoError(t, port.Close())
+}
+
+func Benchmark_LPS331A_SPI_Sense(b *testing.B) {
+	ops := append(init_LPS331ASPIOps(),
+		// CTRL_REG1 setup for continuous measurement
+		conntest.IO{W: []byte{LPS331A_CTRL_REG1, 0xe4}},
+	)
+	for i := 0; i < b.N; i++ {
+		// Read STATUS_REG, PRESS_OUT and TEMP_OUT; RW(bit 7) and MS(bit 6) set
+		ops = append(ops, conntest.IO{
+			W: []byte{0x27 | 0xc0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+			R: []byte{0x00, 0x33, 0x00, 0x50, 0x3f, 0xd0, 0x6b},
+		})
+	}
+	port := spitest.Playback{
+		Playback: conntest.Playback{Ops: ops},
+	}
+
+	d, err := lpsensors.NewSPI(&port, nil)
+	if err != nil {
+		b.Fatalf("lps err: %v", err)
+	}
+
+	data := lpsensors.SensorValues{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := d.Sense(context.TODO(), &data); err != nil {
+			b.Fatalf("sense err: %v", err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package lpsensors
+
+import (
+	"fmt"
+
+	"periph.io/x/conn/v3/physic"
+)
+
+// State is the calibration of the device which can be persisted (e.g. as JSON) across power cycles.
+type State struct {
+	// PressureOffset is the software offset added to every pressure reading.
+	PressureOffset physic.Pressure `json:"pressure_offset"`
+	// TemperatureOffset is the software offset added to every temperature reading.
+	TemperatureOffset physic.Temperature `json:"temperature_offset,omitempty"`
+	// PressureZero is the pressure taken by Zero, subtracted from every pressure reading.
+	PressureZero physic.Pressure `json:"pressure_zero,omitempty"`
+	// ReferencePressure is the content of the REF_P registers, if it was set through the driver.
+	ReferencePressure *physic.Pressure `json:"reference_pressure,omitempty"`
+}
+
+// ExportState returns the current calibration of the device.
+func (d *Dev) ExportState() State {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	s := State{
+		PressureOffset:    d.pressureOffset,
+		TemperatureOffset: d.temperatureOffset,
+		PressureZero:      d.pressureZero,
+	}
+	if d.refP != nil {
+		p := *d.refP
+		s.ReferencePressure = &p
+	}
+	return s
+}
+
+// ImportState restores the calibration exported by ExportState.
+// ReferencePressure, when present, is written to the REF_P registers.
+func (d *Dev) ImportState(s State) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if s.ReferencePressure != nil {
+		if err := d.writeReferencePressure(*s.ReferencePressure); err != nil {
+			return d.wrap(fmt.Errorf("ImportState: %w", err))
+		}
+	}
+	d.pressureOffset = s.PressureOffset
+	d.temperatureOffset = s.TemperatureOffset
+	d.pressureZero = s.PressureZero
+	return nil
+}
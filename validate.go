@@ -0,0 +1,75 @@
+package lpsensors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Validate checks the options are coherent regardless of the chip.
+// Invalid values are reported with ErrInvalidOption, and the settings conflicting
+// with the mode with ErrUnsupportedOption.
+// The options the detected chip does not support are checked at the construction.
+// A nil o means the defaults and is valid.
+func (o *Opts) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	var invalid []string
+	if o.Mode != OneShot && o.Mode != Continuous {
+		invalid = append(invalid, fmt.Sprintf("unknown measurement mode %d", int(o.Mode)))
+	}
+	if _, ok := odrHz[o.ODR]; !ok && o.ODR != ODRDefault {
+		invalid = append(invalid, fmt.Sprintf("unknown %s", o.ODR))
+	}
+	if o.Averaging.Pressure < 0 || o.Averaging.Temperature < 0 {
+		invalid = append(invalid, fmt.Sprintf("negative averaging %+v", o.Averaging))
+	}
+	if o.LowPassFilter.bits() == 0 && o.LowPassFilter != LPFOff {
+		invalid = append(invalid, fmt.Sprintf("unknown %s", o.LowPassFilter))
+	}
+	if o.AddrIncrement < AddrIncDefault || o.AddrIncrement > AddrIncAuto {
+		invalid = append(invalid, fmt.Sprintf("unknown %s", o.AddrIncrement))
+	}
+	if _, ok := fifoMeanPoints[o.FIFOMean]; !ok && o.FIFOMean != 0 {
+		invalid = append(invalid, fmt.Sprintf("FIFOMean %d is not 2, 4, 8, 16 or 32", o.FIFOMean))
+	}
+	if o.OneShotMaxPolls < 0 || o.OneShotPollInterval < 0 || o.OneShotTimeout < 0 {
+		invalid = append(invalid, "negative one-shot polling")
+	}
+	if o.ResetWait < 0 {
+		invalid = append(invalid, "negative ResetWait")
+	}
+	if o.ResetCheckEvery < 0 {
+		invalid = append(invalid, "negative ResetCheckEvery")
+	}
+	if o.Retries < 0 || o.RetryDelay < 0 {
+		invalid = append(invalid, "negative retry")
+	}
+
+	if len(invalid) != 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidOption, strings.Join(invalid, ", "))
+	}
+
+	// Continuous-only settings
+	var conflicts []string
+	if o.Mode == OneShot {
+		if o.ODR != ODRDefault {
+			conflicts = append(conflicts, "ODR")
+		}
+		if o.LowPassFilter != LPFOff {
+			conflicts = append(conflicts, "LowPassFilter")
+		}
+		if o.FIFOMean != 0 {
+			conflicts = append(conflicts, "FIFOMean")
+		}
+		if o.WaitDataReady {
+			conflicts = append(conflicts, "WaitDataReady")
+		}
+	}
+
+	if len(conflicts) != 0 {
+		return fmt.Errorf("%w in OneShot mode: %s", ErrUnsupportedOption, strings.Join(conflicts, ", "))
+	}
+	return nil
+}